@@ -22,7 +22,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.TopologyWeightingAddition, "topology-weighting-addition", "i", "bayes", "Weighting addition method to reweight the network based on network topology. Valid values are: none, bayes, mean, and mult.")
 	// j - used in expression
 	// k - used in expression
-	rootCmd.PersistentFlags().IntVarP(&commonArguments.PathLength, "path-length", "l", 4, "The maximum PathLength to be explored. Only values of 3 and 4 are realistic as lower values are biologically not relevant and higher values are impossible to calculate. 4 is seen as ideal.")
+	rootCmd.PersistentFlags().IntVarP(&commonArguments.PathLength, "path-length", "l", 4, "The maximum PathLength to be explored. Only values of 3 and 4 are realistic as lower values are biologically not relevant and higher values are hard to calculate, unless --bidirectional-search is used. 4 is seen as ideal.")
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.MappingFile, "mapping-file", "m", "", "If mapping from e.g. systematic to trivial names is required, the path to the mapping file must be given here. A header in the format \"# from,to\" must be present at the top of the file (comma separated).")
 	rootCmd.PersistentFlags().StringSliceVarP(&commonArguments.NetworkFiles, "network-file", "n", []string{}, "Path to the network file. This parameter can be repeated. See example files for the format.")
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.OutputFolder, "output-folder", "o", "", "Path to desired output folder.")
@@ -40,9 +40,26 @@ func init() {
 
 	// flags without shorthand
 	rootCmd.PersistentFlags().Float64VarP(&commonArguments.MinEdgeScore, "min-edge-score", "", 0.0, "The minimal edge score, lower scoring edges are rejected")
+	rootCmd.PersistentFlags().BoolVarP(&commonArguments.BidirectionalSearch, "bidirectional-search", "", false, "Search QTL and EQTL paths from both ends and join them in the middle. This makes longer paths, e.g. of length 5, feasible in sparse networks. Cannot be combined with --path-pattern.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.PathPattern, "path-pattern", "", "", "Only accept paths that match this pattern, e.g. \"first:regulatory, any*, last:type=pd, direction=downstream\". Step clauses combine any, regulatory, non-regulatory, type=<type>|<type>, up and down with & and !, and end in * to match zero or more interactions. The direction clause is one of downstream, upstream, updownstream, downupstream or any. Overrides the default path definition of each path type.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.TopologyWeightingMethod, "topology-weighting-method", "", "degree", "Method to derive the gene scores of the topology weighting. Valid values are: degree (a sigmoid of the degree, penalizing hubs), rwr (random walk with restart) and heat (heat kernel). The rwr and heat methods diffuse the per-gene mutation relevance, or the expression scores for expression path finding, over the network.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.HubCentrality, "hub-centrality", "", "out-degree", "The centrality that the degree topology weighting method penalizes with a sigmoid. Valid values are: out-degree, in-degree, degree (in- plus out-degree), pagerank and betweenness (approximated by sampling source genes). Betweenness penalizes bottleneck hubs, e.g. in signalling networks.")
+	rootCmd.PersistentFlags().Float64VarP(&commonArguments.PropagationRestart, "propagation-restart", "", 0.5, "The restart probability of the rwr topology weighting method.")
+	rootCmd.PersistentFlags().Float64VarP(&commonArguments.HeatKernelTime, "heat-kernel-time", "", 1.0, "The diffusion time of the heat topology weighting method.")
+	rootCmd.PersistentFlags().StringSliceVarP(&commonArguments.BannedGenes, "banned-genes", "", []string{}, "Genes to remove from the network, with all their interactions. Each value is a gene name, a regular expression that matches the whole gene name, or the path to a file with one name or regular expression per line. This parameter can be repeated.")
+	rootCmd.PersistentFlags().IntVarP(&commonArguments.MaxDegree, "max-degree", "", 0, "Remove the genes that interact with more than this number of other genes, such as ubiquitous hubs, from the network with all their interactions. No genes are removed when 0.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.NetworkFormat, "network-format", "", "auto", "The format of the network files. Valid values are: auto, gonetic, sif, graphml, string (STRING protein.links, the combined score is divided by 1000), and biogrid (BioGRID TAB3). The auto format detects the format of each file from its extension and its first line.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.NetworkTypeMapping, "network-type-mapping", "", "", "Path to a tab-separated file that maps the interaction labels of sif, graphml, string and biogrid networks onto interaction types, with the columns: label, interaction type, regulatory|non-regulatory and directed|undirected. Unmapped labels are non-regulatory interaction types of their own.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.NetworkSources, "network-sources", "", "", "Path to a tab-separated network source config. Lines \"type <interaction type> <multiplier>\" and \"file <network file> <multiplier>\" scale the probabilities of the interactions of an interaction type or a network file with a multiplier in [0, 1], where files match on their path or base name. The line \"merge <rule>\" sets how interactions that occur in several network files are merged: max (default), noisy-or or mean.")
+	rootCmd.PersistentFlags().IntVarP(&commonArguments.Bootstrap, "bootstrap", "", 0, "The number of bootstrap replicates. Each replicate resamples the conditions of the input data with replacement and reruns the path finding and the optimization. The stability.network of each resulting network annotates the weighted.network with the fraction of replicates that select each interaction, and the bootstrap folder summarizes the selection frequencies of all genes and interactions.")
+	rootCmd.PersistentFlags().BoolVarP(&commonArguments.LeaveOneOut, "leave-one-out", "", false, "Drop each condition in turn, reoptimize and compare the resulting networks with those of the full data. The paths and d-DNNFs of the full data are reused where the dropped condition does not affect them.")
+	rootCmd.PersistentFlags().Float64VarP(&commonArguments.LeaveOneOutCutoff, "leave-one-out-cutoff", "", 0.5, "Conditions whose removal reduces the Jaccard overlap of the interactions of a resulting network with the full data below this cutoff are reported as influential.")
+	rootCmd.PersistentFlags().IntVarP(&commonArguments.NullNetworks, "null-networks", "", 0, "The number of degree-preserving randomizations of the network on which the full analysis is repeated. Interactions are swapped within their interaction type, preserving their direction. The null-networks folder reports how often each gene and interaction of the selected networks is recovered from the randomized networks.")
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.PathCompression, "path-compression", "", "none", "Compression of the path files, possible values are none, gzip or zstd. Compressed path files are detected and read transparently, regardless of this setting.")
 	rootCmd.PersistentFlags().IntVarP(&commonArguments.BestPathCount, "best-path-count", "", 25, "Number of paths per possible pair. Increasing this might yield better results but is at the expense of longer computational times")
 
 	// Resource flags
+	rootCmd.PersistentFlags().StringVarP(&commonArguments.InteractionIDEncoding, "interaction-id-encoding", "", "compact", "Encoding of the interactions, possible values are compact or wide. The compact encoding supports gene IDs below 2^29 and at most 64 interaction types, and stops with an error when these limits are exceeded. The wide encoding supports any number of genes and interaction types, at the cost of a lookup table for the interactions that exceed the compact limits.")
 	rootCmd.PersistentFlags().IntVarP(&commonArguments.NumCPU, "numCPU", "", runtime.NumCPU()-2, "Limits the number of logical CPU cores that can be used.")
 
 	// Logging flags
@@ -58,6 +75,7 @@ func init() {
 	// Precomputed files flags
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.UseIndex, "use-index", "", "", "Use gene index in the provided file as a base.")
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.UsePaths, "use-paths", "", "", "Use paths in the given directory instead of finding new paths. Requires --use-index. Enables --skip-path-finding.")
+	rootCmd.PersistentFlags().BoolVarP(&commonArguments.ForcePaths, "force-paths", "", false, "Use the paths in --use-paths even if their header shows they were found with incompatible settings, e.g. another network or path length.")
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.UseNNFs, "use-nnfs", "", "", "Use NNFs in the given directory instead of compiling from paths. Requires --use-paths. Enables --skip-compilation.")
 
 	// Skip flags
@@ -70,7 +88,9 @@ func init() {
 	// Multi objective core parameters
 	rootCmd.PersistentFlags().IntVarP(&commonArguments.NumGens, "generations-count", "", -1, "The amount of generations used in the multi-objective optimization algorithm")
 	rootCmd.PersistentFlags().Float64VarP(&commonArguments.MutChance, "mutation-chance", "", 0.5, "The mutation chance used by the multi-objective optimization algorithm")
+	rootCmd.PersistentFlags().BoolVarP(&commonArguments.AdaptiveOperatorRates, "adaptive-operator-rates", "", false, "Adapt the mutation chance and the balance between expansion and reduction mutations every generation, based on how often their children enter the next population. The mutation chance is used as the initial value.")
 	rootCmd.PersistentFlags().IntVarP(&commonArguments.PopSize, "population-size", "", 500, "The population size used by the multi-objective optimization algorithm")
+	rootCmd.PersistentFlags().StringSliceVarP(&commonArguments.Crossover, "crossover", "", []string{"alternating"}, "The crossover operators used by the multi-objective optimization algorithm. Valid values are: alternating, uniform, gene-anchored, and condition-wise. When multiple operators are given, one is chosen at random for every child. This parameter can be repeated.")
 
 	// Scoring parameters
 	rootCmd.PersistentFlags().BoolVarP(&commonArguments.OptimizeNetworkSize, "optimize-network-size", "", true, "Force parsimony pressure on the network size")
@@ -78,6 +98,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&commonArguments.SampleObjectiveType, "sample-objective-type", "", "entropy", "The type of sample objective to use. Possible values are \"entropy\" or \"effective\".")
 	rootCmd.PersistentFlags().IntVarP(&commonArguments.TargetNetworkSize, "target-network-size", "x", 100, "The target network size used in the optimization")
 
+	// Interpretation parameters
+	rootCmd.PersistentFlags().StringSliceVarP(&commonArguments.Selection, "selection", "", []string{}, "Additional strategies to select the resulting network from the final front, each written to resulting_networks/<strategy>. Valid values are: knee, weighted-sum, and hv-contribution. This parameter can be repeated.")
+	rootCmd.PersistentFlags().Float64SliceVarP(&commonArguments.SelectionWeights, "selection-weights", "", []float64{}, "The weight per objective used by the weighted-sum selection, applied to the normalized scores. The objectives are ordered as network size (if optimized), sample count (if optimized), and one objective per path type. All weights are 1 by default.")
+
 	// Multi objective early termination
 	rootCmd.PersistentFlags().Float64VarP(&commonArguments.TimeLimitHours, "max-hours", "", 0, "The maximum number of hours to run the optimization before forced termination. By default no time limit is imposed")
 	rootCmd.PersistentFlags().IntVarP(&commonArguments.WindowCount, "window-count", "", 20, "The number of generation windows used in the optimization")
@@ -14,6 +14,7 @@ func init() {
 
 	// flags without shorthand
 	eqtl.PersistentFlags().BoolVarP(&eqtlArguments.Regulatory, "regulatory", "", false, "Boolean whether or not the last interaction pointing to a differential expressed gene should be a regulatory interaction")
+	eqtl.PersistentFlags().BoolVarP(&eqtlArguments.SignConsistent, "sign-consistent", "", false, "Boolean whether or not to keep only the paths whose net sign, the product of the activation (+) and inhibition (-) signs of their interactions, agrees with the direction of the lfc of the differentially expressed gene in the expression file. Paths through unsigned interactions are discarded.")
 	eqtl.PersistentFlags().BoolVarP(&eqtlArguments.WithExpression, "with-expression", "", false, "Boolean whether or not to use within-sample expression optimisation")
 	eqtl.PersistentFlags().BoolVarP(&eqtlArguments.WithMutation, "with-mutation", "", true, "Boolean whether or not to use across-sample mutation optimisation")
 
@@ -21,12 +21,15 @@ func initExpression(expressionCmd *cobra.Command) {
 	// flags with shorthand
 	expressionCmd.PersistentFlags().StringVarP(&expressionArguments.ExpressionFile, "expression-file", "a", "", "File containing the differential expression data. This should be tab delimited with following informatiom <<gene name>> <<P value> <<LFC>> <<condition>> with the appropriate header.")
 	expressionCmd.PersistentFlags().StringVarP(&expressionArguments.DifferentialExpressionList, "differential-expression-file", "g", "", "Path to file containing which genes to consider as differentially expressed. This should be tab delimited with following information <<gene name>> <<condition>> with the appropriate header.")
-	expressionCmd.PersistentFlags().StringVarP(&expressionArguments.ExpressionWeightingMethod, "expression-weighting-method", "j", "lfc", "Weighting method to use for expression networks. Valid values are: none, lfc, and zscore.")
+	expressionCmd.PersistentFlags().StringVarP(&expressionArguments.ExpressionWeightingMethod, "expression-weighting-method", "j", "lfc", "Weighting method to use for expression networks. Valid values are: none, lfc, zscore, and activity.")
 	expressionCmd.PersistentFlags().StringVarP(&expressionArguments.ExpressionWeightingAddition, "expression-weighting-addition", "k", "mean", "Weighting addition method to use for expression networks. Valid values are: none, bayes, mean, and mult.")
 
 	// flags without shorthand
 	expressionCmd.PersistentFlags().Float64VarP(&expressionArguments.ExpressionWeightingDefault, "expression-weighting-default", "", 0.0, "The default weighting probability when no expression data is available.")
 	expressionCmd.PersistentFlags().BoolVarP(&expressionArguments.DownUpstream, "down-up-stream", "", true, "Boolean flag to determine GoNetic expression mode: true runs the 'downstream' configuration, false the 'upstream' configuration.")
+	expressionCmd.PersistentFlags().StringSliceVarP(&expressionArguments.DETables, "de-tables", "", []string{}, "DESeq2 or edgeR result tables to use instead of the expression file, comma or tab separated and optionally gzip compressed. Give one table per condition as <<condition>>=<<file>>, or a table with a condition column. Tables without either use their file name as condition. The lfc is log2FoldChange or logFC, and the zscore is the Wald statistic stat of DESeq2, or is derived from the PValue and the sign of the logFC for edgeR. Unless a differential expression file is given, the differentially expressed genes are called with --padj-cutoff and --lfc-cutoff. This parameter can be repeated.")
+	expressionCmd.PersistentFlags().Float64VarP(&expressionArguments.PadjCutoff, "padj-cutoff", "", 0.05, "The maximal adjusted p-value, padj or FDR, of the genes called differentially expressed from the --de-tables.")
+	expressionCmd.PersistentFlags().Float64VarP(&expressionArguments.LFCCutoff, "lfc-cutoff", "", 1, "The minimal absolute log2 fold change of the genes called differentially expressed from the --de-tables.")
 	expressionCmd.PersistentFlags().BoolVarP(&expressionArguments.PrintGeneScoreMap, "print-gene-score-map", "", false, "Boolean flag to print the gene scores used for weighting the network.")
 }
 
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MarchalLab/gonetic/internal/run"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	// add the command to the root
+	rootCmd.AddCommand(networkStats)
+}
+
+var networkStats = &cobra.Command{
+	Use:   "network-stats [options...]",
+	Short: "network statistics: network-stats",
+	Long:  `network statistics: loads the networks as the path finding does, and reports their gene, interaction and interaction type counts, connected components, degree distribution, power law fit and predicted search tree cutoff.`,
+	Args:  cobra.MinimumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := commonArguments.Init()
+		if err != nil {
+			panic(fmt.Sprintf("Error initializing network-stats arguments %s", err))
+		}
+		commonArguments.Info("Running network-stats",
+			"args", fmt.Sprintf("%+v", args),
+			"Common", fmt.Sprintf("%+v", commonArguments),
+		)
+		run.NetworkStats(commonArguments)
+	},
+}
@@ -28,7 +28,17 @@ func initQTL(qtlCmd *cobra.Command) {
 
 	// flags without shorthand
 	qtlCmd.PersistentFlags().BoolVarP(&qtlSpecificArguments.Correction, "correction", "", false, "Boolean whether or not to include mutator outlier correction.")
+	qtlCmd.PersistentFlags().StringVarP(&qtlSpecificArguments.MutationFormat, "mutation-format", "", "auto", "The format of the mutation data file. Valid values are: auto, csv, vcf and maf. VCF files take the gene from the first ANN or CSQ annotation with a gene, the conditions from the sample columns whose genotype carries an alternative allele, and the freq increase from the allele depths AD divided by DP. MAF files take the gene from Hugo_Symbol, the condition from Tumor_Sample_Barcode and the freq increase from t_alt_count divided by t_depth. VCF and MAF files can be gzip compressed. The auto format detects the format from the extension and the first line of the file.")
+	qtlCmd.PersistentFlags().StringSliceVarP(&qtlSpecificArguments.VariantClassifications, "variant-classifications", "", []string{}, "Only keep the variants of VCF and MAF mutation files with one of these classifications, e.g. missense_variant or Missense_Mutation. The classification is the consequence of the ANN or CSQ annotation of VCF files and the Variant_Classification of MAF files. All variants are kept when empty. This parameter can be repeated.")
+	qtlCmd.PersistentFlags().StringSliceVarP(&qtlSpecificArguments.ExcludedVariantClassifications, "exclude-variant-classifications", "", []string{}, "Discard the variants of VCF and MAF mutation files with one of these classifications, e.g. synonymous_variant or Silent. This parameter can be repeated.")
+	qtlCmd.PersistentFlags().StringVarP(&qtlSpecificArguments.CNADataFile, "cna-data-file", "", "", "Optional file containing gene-level copy-number alterations, which are searched as an additional cna path type alongside the mutations. This is either a separated file with the headers <<gene name>> <<condition>> <<copy number>> and optionally <<focality>>, or a GISTIC gene table such as all_thresholded.by_genes.txt. The copy numbers are thresholded values from -2 to 2, and the focality is the fraction in [0, 1] that the alteration is focal rather than broad. The relevance of an altered gene is its absolute copy number divided by 2, times its focality.")
 	qtlCmd.PersistentFlags().BoolVarP(&qtlSpecificArguments.WithinCondition, "within-condition", "", false, "Enable if paths should also be detected within a condition.")
+	qtlCmd.PersistentFlags().StringVarP(&qtlSpecificArguments.SampleWeightingFile, "sample-weighting-file", "", "", "Optional file containing per-sample expression or gene activity data, used to weight the mutation network per sample. This should be tab delimited with following information <<gene name>> <<condition>> <<value>> with the appropriate header, where the value column is named after the weighting method. Samples without data use the unweighted network.")
+	qtlCmd.PersistentFlags().StringVarP(&qtlSpecificArguments.SampleWeightingMethod, "sample-weighting-method", "", "activity", "Weighting method to use for the per-sample networks. Valid values are: lfc, zscore, and activity. Activities are used as gene probabilities directly.")
+	qtlCmd.PersistentFlags().StringVarP(&qtlSpecificArguments.SampleWeightingAddition, "sample-weighting-addition", "", "mean", "Weighting addition method to use for the per-sample networks. Valid values are: none, bayes, mean, and mult.")
+	qtlCmd.PersistentFlags().IntVarP(&qtlSpecificArguments.Permutations, "permutations", "", 0, "The number of permutations used to compute empirical p-values for the condition-specific gene rankings. Each permutation reshuffles the mutations and the copy-number alterations across the genes of the network within each sample, preserving the number of altered genes per sample, and reruns the path finding and a reduced optimization. The null distributions are written to the permutations folder.")
+	qtlCmd.PersistentFlags().IntVarP(&qtlSpecificArguments.PermutationGenerations, "permutation-generations", "", 100, "The amount of generations used in the reduced optimization of each permutation.")
+	qtlCmd.PersistentFlags().Float64VarP(&qtlSpecificArguments.SampleWeightingDefault, "sample-weighting-default", "", 0.0, "The default weighting probability for genes without data in a sample.")
 }
 
 var qtlSpecificArguments = arguments.QTLSpecific{}
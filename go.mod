@@ -6,7 +6,10 @@ toolchain go1.24.2
 
 require github.com/spf13/cobra v1.8.1 // cmd
 
-require gonum.org/v1/gonum v0.16.0
+require (
+	github.com/klauspost/compress v1.17.11
+	gonum.org/v1/gonum v0.16.0
+)
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
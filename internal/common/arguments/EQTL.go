@@ -4,6 +4,19 @@ type EQTL struct {
 	*Expression
 	*QTLSpecific
 	Regulatory     bool
+	SignConsistent bool
 	WithMutation   bool
 	WithExpression bool
 }
+
+// Init initializes the common arguments and checks the QTL specific arguments
+func (arguments *EQTL) Init() error {
+	if err := arguments.Common.Init(); err != nil {
+		return err
+	}
+	if err := arguments.QTLSpecific.validate(); err != nil {
+		arguments.Error("Invalid QTL arguments", "err", err)
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,11 @@ type Expression struct {
 	ExpressionFile             string
 	DifferentialExpressionList string
 
+	// DESeq2 and edgeR result tables, and the thresholds of their differentially expressed genes
+	DETables   []string
+	PadjCutoff float64
+	LFCCutoff  float64
+
 	// Expression weighting
 	ExpressionWeightingMethod   string
 	ExpressionWeightingAddition string
@@ -1,5 +1,7 @@
 package arguments
 
+import "fmt"
+
 type QTL struct {
 	*Common
 	*QTLSpecific
@@ -12,7 +14,55 @@ type QTLSpecific struct {
 
 	MutRateParam       float64
 	MutationDataFile   string
+	MutationFormat     string
 	FreqCutoff         float64
 	WithinCondition    bool
 	OutlierPopulations string
+
+	// Variant classification filters of VCF and MAF mutation files
+	VariantClassifications         []string
+	ExcludedVariantClassifications []string
+
+	// Copy-number alterations, searched as the cna path type
+	CNADataFile string
+
+	// Per-sample network weighting
+	SampleWeightingFile     string
+	SampleWeightingMethod   string
+	SampleWeightingAddition string
+	SampleWeightingDefault  float64
+
+	// Permutation-based significance
+	Permutations           int
+	PermutationGenerations int
+}
+
+// Init initializes the common arguments and checks the QTL specific arguments
+func (arguments *QTL) Init() error {
+	if err := arguments.Common.Init(); err != nil {
+		return err
+	}
+	if err := arguments.QTLSpecific.validate(); err != nil {
+		arguments.Error("Invalid QTL arguments", "err", err)
+		return err
+	}
+	return nil
+}
+
+// validate checks the settings of the per-sample network weighting, which are only used with a sample weighting file
+func (arguments *QTLSpecific) validate() error {
+	if arguments.SampleWeightingFile == "" {
+		return nil
+	}
+	switch arguments.SampleWeightingMethod {
+	case "lfc", "zscore", "activity":
+	default:
+		return fmt.Errorf("unknown sample weighting method %q, valid values are: lfc, zscore, and activity", arguments.SampleWeightingMethod)
+	}
+	switch arguments.SampleWeightingAddition {
+	case "none", "bayes", "mean", "mult":
+	default:
+		return fmt.Errorf("unknown sample weighting addition %q, valid values are: none, bayes, mean, and mult", arguments.SampleWeightingAddition)
+	}
+	return nil
 }
@@ -0,0 +1,51 @@
+package arguments
+
+import (
+	"testing"
+)
+
+func TestQTL_Init_SampleWeighting(t *testing.T) {
+	testCases := []struct {
+		name        string
+		file        string
+		method      string
+		addition    string
+		expectError bool
+	}{
+		{"NoSampleWeighting", "", "", "", false},
+		{"Defaults", "samples.txt", "activity", "mean", false},
+		{"Lfc", "samples.txt", "lfc", "bayes", false},
+		{"UnknownMethod", "samples.txt", "activities", "mean", true},
+		{"UnknownAddition", "samples.txt", "zscore", "sum", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args := &QTL{
+				Common: NewCommon(),
+				QTLSpecific: &QTLSpecific{
+					SampleWeightingFile:     testCase.file,
+					SampleWeightingMethod:   testCase.method,
+					SampleWeightingAddition: testCase.addition,
+				},
+			}
+			err := args.Init()
+			if testCase.expectError && err == nil {
+				t.Errorf("Expected error, but none occurred")
+			}
+			if !testCase.expectError && err != nil {
+				t.Errorf("Did not expect error, but got %v", err)
+			}
+
+			// the EQTL setting checks the same arguments
+			eqtlArgs := &EQTL{
+				Expression:  &Expression{Common: NewCommon()},
+				QTLSpecific: args.QTLSpecific,
+			}
+			err = eqtlArgs.Init()
+			if testCase.expectError != (err != nil) {
+				t.Errorf("Expected error %v in the EQTL setting, got %v", testCase.expectError, err)
+			}
+		})
+	}
+}
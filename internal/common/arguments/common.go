@@ -17,6 +17,21 @@ import (
 	"github.com/MarchalLab/gonetic/internal/common/types"
 )
 
+// names of the strategies that can be requested with --selection
+const (
+	KneeSelection           = "knee"
+	WeightedSumSelection    = "weighted-sum"
+	HVContributionSelection = "hv-contribution"
+)
+
+// names of the operators that can be requested with --crossover
+const (
+	AlternatingCrossover   = "alternating"
+	UniformCrossover       = "uniform"
+	GeneAnchoredCrossover  = "gene-anchored"
+	ConditionWiseCrossover = "condition-wise"
+)
+
 // TODO: temporary global interaction store, should be removed after the transition is complete
 var GlobalInteractionStore = types.NewInteractionStore()
 
@@ -34,6 +49,10 @@ type Common struct {
 	OutputFolder       string
 	NetworkFiles       []string
 	BannedNetworkFiles []string
+	BannedGenes        []string
+	NetworkFormat      string
+	NetworkTypeMapping string
+	NetworkSources     string
 	EtcPathAsString    string
 	MappingFile        string
 	// General settings
@@ -42,12 +61,25 @@ type Common struct {
 	LogFile                   string
 	Verbose                   bool
 	TopologyWeightingAddition string
+	TopologyWeightingMethod   string
+	HubCentrality             string
+	PropagationRestart        float64
+	HeatKernelTime            float64
 	MinEdgeScore              float64
+	MaxDegree                 int
+	InteractionIDEncoding     string
 	// Path-finding settings
-	PathTypes     []string
-	PathLength    int
-	BestPathCount int
-	SldCutoff     float64
+	PathTypes           []string
+	PathLength          int
+	BestPathCount       int
+	SldCutoff           float64
+	BidirectionalSearch bool
+	PathCompression     string
+	PathPattern         string
+	NullNetworks        int
+	Bootstrap           int
+	LeaveOneOut         bool
+	LeaveOneOutCutoff   float64
 	// Optimization settings
 	MaxPaths                   int
 	NumGens                    int
@@ -58,6 +90,8 @@ type Common struct {
 	MaxWindowSize              int
 	RequiredProgressPercentage float64
 	MutChance                  float64
+	AdaptiveOperatorRates      bool
+	Crossover                  []string
 	PopSize                    int
 	OptimizeNetworkSize        bool
 	OptimizeSampleCount        bool
@@ -65,10 +99,13 @@ type Common struct {
 	TargetNetworkSize          int
 	SampleObjectiveType        string
 	// Interpretation settings
+	Selection        []string
+	SelectionWeights []float64
 	// Skips
 	UseIndex            string
 	SkipPathFinding     bool
 	UsePaths            string
+	ForcePaths          bool
 	SkipCompilation     bool
 	UseNNFs             string
 	SkipOptimization    bool
@@ -109,8 +146,9 @@ func (arguments *Common) PathsFileWithName(dir, filename string) string {
 	return filepath.Join(arguments.PathsDirectory(dir), filename)
 }
 
+// PathsFile returns the combined paths file, with the extension of the path compression
 func (arguments *Common) PathsFile(dir string) string {
-	return filepath.Join(arguments.PathsDirectory(dir), pathsFileName)
+	return fileio.CompressedFileName(filepath.Join(arguments.PathsDirectory(dir), pathsFileName), arguments.PathCompression)
 }
 
 func (arguments *Common) WeightsFile(dir, prefix string) string {
@@ -135,6 +173,7 @@ func (arguments *Common) FrontsDirectory() string {
 const resultsDirectoryName = "resulting_networks"
 const filledDirectoryName = "filled_networks"
 const weightedNetworkFileName = "weighted.network"
+const stabilityNetworkFileName = "stability.network"
 
 func (arguments *Common) ResultsDirectory(pathType string) string {
 	return filepath.Join(arguments.OutputFolder, resultsDirectoryName, pathType)
@@ -148,6 +187,10 @@ func (arguments *Common) WeightedNetworkFile(directory string) string {
 	return filepath.Join(directory, weightedNetworkFileName)
 }
 
+func (arguments *Common) StabilityNetworkFile(directory string) string {
+	return filepath.Join(directory, stabilityNetworkFileName)
+}
+
 const nnfDirectoryName = "NF"
 
 func (arguments *Common) NormalFormDirectory() string {
@@ -200,6 +243,11 @@ func (arguments *Common) geneMapFileToWrite() string {
 	return filepath.Join(arguments.OutputFolder, "gene-ids")
 }
 
+// RemovedGenesFile returns the path to the file with the genes that were removed from the network, and the reason
+func (arguments *Common) RemovedGenesFile() string {
+	return filepath.Join(arguments.OutputFolder, "removed-genes")
+}
+
 // interactionTypeFileToWrite returns the path to the interaction type map file that will be written to
 func (arguments *Common) interactionTypeFileToWrite() string {
 	return filepath.Join(arguments.OutputFolder, "interaction-type-ids")
@@ -266,6 +314,14 @@ func (arguments *Common) Init() error {
 		)
 		return errors.New("invalid use of precomputed files")
 	}
+	// paths are not compressed by default
+	if arguments.PathCompression == "" {
+		arguments.PathCompression = fileio.NoCompression
+	}
+	if err := fileio.ValidateCompression(arguments.PathCompression); err != nil {
+		arguments.Error("Invalid path compression", "err", err)
+		return err
+	}
 	// MaxPaths should be positive
 	arguments.MaxPaths = max(arguments.MaxPaths, 0)
 
@@ -274,6 +330,53 @@ func (arguments *Common) Init() error {
 		arguments.SampleObjectiveType = "entropy"
 	}
 
+	// the topology weighting method is the degree weighting by default
+	switch arguments.TopologyWeightingMethod {
+	case "":
+		arguments.TopologyWeightingMethod = "degree"
+	case "degree", "rwr", "heat":
+	default:
+		err := fmt.Errorf("unknown topology weighting method %q, valid values are: degree, rwr, and heat", arguments.TopologyWeightingMethod)
+		arguments.Error("Invalid topology weighting method", "err", err)
+		return err
+	}
+
+	switch arguments.HubCentrality {
+	case "", "out-degree", "in-degree", "degree", "pagerank", "betweenness":
+	default:
+		err := fmt.Errorf("unknown hub centrality %q, valid values are: out-degree, in-degree, degree, pagerank, and betweenness", arguments.HubCentrality)
+		arguments.Error("Invalid hub centrality", "err", err)
+		return err
+	}
+
+	// a path pattern is matched from one end of the paths, which the bidirectional search cannot do
+	if arguments.BidirectionalSearch && arguments.PathPattern != "" {
+		err := errors.New("--bidirectional-search cannot be combined with --path-pattern")
+		arguments.Error("Invalid path search", "err", err)
+		return err
+	}
+
+	// the crossover operators are checked before anything is computed, alternating crossover is the default
+	if len(arguments.Crossover) == 0 {
+		arguments.Crossover = []string{AlternatingCrossover}
+	}
+	for _, name := range arguments.Crossover {
+		switch name {
+		case AlternatingCrossover, UniformCrossover, GeneAnchoredCrossover, ConditionWiseCrossover:
+		default:
+			err := fmt.Errorf("unknown crossover operator %q, valid values are: %s, %s, %s, and %s",
+				name, AlternatingCrossover, UniformCrossover, GeneAnchoredCrossover, ConditionWiseCrossover)
+			arguments.Error("Invalid crossover operator", "err", err)
+			return err
+		}
+	}
+
+	// the selection strategies are checked before anything is computed
+	if err := arguments.validateSelection(); err != nil {
+		arguments.Error("Invalid selection", "err", err)
+		return err
+	}
+
 	// set the number of logical CPU cores to use
 	if arguments.NumCPU < 1 {
 		arguments.NumCPU = 1
@@ -282,6 +385,17 @@ func (arguments *Common) Init() error {
 	arguments.Sem = semaphore.NewSemaphore(arguments.NumCPU)
 	logger.Info("Using CPUs", "maxProcs", runtime.GOMAXPROCS(0), "numCPU", runtime.NumCPU())
 
+	// set the interaction ID encoding before any interaction is read
+	if arguments.InteractionIDEncoding == "" {
+		arguments.InteractionIDEncoding = "compact"
+	}
+	encoding, err := types.ParseInteractionIDEncoding(arguments.InteractionIDEncoding)
+	if err != nil {
+		arguments.Error("Invalid interaction ID encoding", "err", err)
+		return err
+	}
+	types.SetInteractionIDEncoding(encoding)
+
 	// create the gene - id and interaction - id maps
 	arguments.GeneIDMap = types.NewGeneIDMap()
 	arguments.InteractionStore = GlobalInteractionStore
@@ -296,6 +410,46 @@ func (arguments *Common) Init() error {
 	return nil
 }
 
+// validateSelection checks that the selection strategies are known, and that the selection weights match the
+// objectives if the path types are known
+func (arguments *Common) validateSelection() error {
+	for _, strategy := range arguments.Selection {
+		switch strategy {
+		case KneeSelection, WeightedSumSelection, HVContributionSelection:
+		default:
+			return fmt.Errorf("unknown selection strategy %q, valid values are: %s, %s, and %s",
+				strategy, KneeSelection, WeightedSumSelection, HVContributionSelection)
+		}
+	}
+	if len(arguments.PathTypes) == 0 {
+		return nil
+	}
+	return arguments.CheckSelectionWeights()
+}
+
+// ObjectiveCount returns the number of objectives of the optimization, which are the network size and the sample
+// count if they are optimized, and one objective per path type
+func (arguments *Common) ObjectiveCount() int {
+	count := len(arguments.PathTypes)
+	if arguments.OptimizeNetworkSize {
+		count++
+	}
+	if arguments.OptimizeSampleCount {
+		count++
+	}
+	return count
+}
+
+// CheckSelectionWeights checks that the selection weights, if any, match the number of objectives, which is only
+// known once the path types are set
+func (arguments *Common) CheckSelectionWeights() error {
+	if len(arguments.SelectionWeights) == 0 || len(arguments.SelectionWeights) == arguments.ObjectiveCount() {
+		return nil
+	}
+	return fmt.Errorf("got %d selection weights for %d objectives",
+		len(arguments.SelectionWeights), arguments.ObjectiveCount())
+}
+
 func createLogFile(filename string) (*os.File, error) {
 	// Create the directory if it doesn't exist
 	dir := filepath.Dir(filename)
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"testing"
 )
 
@@ -226,6 +227,164 @@ func TestCommon_Init_UsePrecomputed(t *testing.T) {
 	}
 }
 
+func TestCommon_Init_Selection(t *testing.T) {
+	testCases := []struct {
+		name        string
+		selection   []string
+		weights     []float64
+		pathTypes   []string
+		expectError bool
+	}{
+		{"NoSelection", nil, nil, nil, false},
+		{"KnownStrategies", []string{"knee", "weighted-sum", "hv-contribution"}, nil, nil, false},
+		{"UnknownStrategy", []string{"knee", "Knee"}, nil, nil, true},
+		{"WeightsWithoutPathTypes", []string{"weighted-sum"}, []float64{1, 2, 3}, nil, false},
+		{"MatchingWeights", []string{"weighted-sum"}, []float64{1, 2}, []string{"mutation"}, false},
+		{"MismatchingWeights", []string{"weighted-sum"}, []float64{1, 2, 3}, []string{"mutation"}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args := NewCommon()
+			args.Selection = testCase.selection
+			args.SelectionWeights = testCase.weights
+			args.PathTypes = testCase.pathTypes
+			args.OptimizeNetworkSize = true
+
+			err := args.Init()
+
+			if testCase.expectError && err == nil {
+				t.Errorf("Expected error, but none occurred")
+			}
+			if !testCase.expectError && err != nil {
+				t.Errorf("Did not expect error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestCommon_Init_Crossover(t *testing.T) {
+	testCases := []struct {
+		name        string
+		crossover   []string
+		expected    []string
+		expectError bool
+	}{
+		{"DefaultCrossover", nil, []string{"alternating"}, false},
+		{"KnownOperators", []string{"uniform", "gene-anchored", "condition-wise"}, []string{"uniform", "gene-anchored", "condition-wise"}, false},
+		{"UnknownOperator", []string{"alternating", "single-point"}, []string{"alternating", "single-point"}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args := NewCommon()
+			args.Crossover = testCase.crossover
+
+			err := args.Init()
+
+			if testCase.expectError && err == nil {
+				t.Errorf("Expected error, but none occurred")
+			}
+			if !testCase.expectError && err != nil {
+				t.Errorf("Did not expect error, but got %v", err)
+			}
+			if !slices.Equal(args.Crossover, testCase.expected) {
+				t.Errorf("Expected crossover operators %v, got %v", testCase.expected, args.Crossover)
+			}
+		})
+	}
+}
+
+func TestCommon_Init_BidirectionalPathPattern(t *testing.T) {
+	testCases := []struct {
+		name          string
+		bidirectional bool
+		pattern       string
+		expectError   bool
+	}{
+		{"Bidirectional", true, "", false},
+		{"PathPattern", false, "pp*", false},
+		{"BidirectionalPathPattern", true, "pp*", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args := NewCommon()
+			args.BidirectionalSearch = testCase.bidirectional
+			args.PathPattern = testCase.pattern
+
+			err := args.Init()
+
+			if testCase.expectError && err == nil {
+				t.Errorf("Expected error, but none occurred")
+			}
+			if !testCase.expectError && err != nil {
+				t.Errorf("Did not expect error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestCommon_Init_TopologyWeighting(t *testing.T) {
+	testCases := []struct {
+		name          string
+		method        string
+		hubCentrality string
+		expected      string
+		expectError   bool
+	}{
+		{"DefaultMethod", "", "", "degree", false},
+		{"Degree", "degree", "betweenness", "degree", false},
+		{"RWR", "rwr", "", "rwr", false},
+		{"Heat", "heat", "", "heat", false},
+		{"UnknownMethod", "RWR", "", "RWR", true},
+		{"UnknownHubCentrality", "degree", "closeness", "degree", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args := NewCommon()
+			args.TopologyWeightingMethod = testCase.method
+			args.HubCentrality = testCase.hubCentrality
+
+			err := args.Init()
+
+			if testCase.expectError && err == nil {
+				t.Errorf("Expected error, but none occurred")
+			}
+			if !testCase.expectError && err != nil {
+				t.Errorf("Did not expect error, but got %v", err)
+			}
+			if args.TopologyWeightingMethod != testCase.expected {
+				t.Errorf("Expected topology weighting method %q, got %q", testCase.expected, args.TopologyWeightingMethod)
+			}
+		})
+	}
+}
+
+// TestCommon_CheckSelectionWeights tests that the selection weights are checked against the objectives
+func TestCommon_CheckSelectionWeights(t *testing.T) {
+	args := Common{
+		PathTypes:           []string{"eqtl", "mutation"},
+		OptimizeNetworkSize: true,
+		OptimizeSampleCount: true,
+	}
+	if count := args.ObjectiveCount(); count != 4 {
+		t.Errorf("Expected 4 objectives, got %d", count)
+	}
+	if err := args.CheckSelectionWeights(); err != nil {
+		t.Errorf("Expected the default weights to be valid, got %v", err)
+	}
+	args.SelectionWeights = []float64{1, 1, 1, 1}
+	if err := args.CheckSelectionWeights(); err != nil {
+		t.Errorf("Expected matching weights to be valid, got %v", err)
+	}
+	args.OptimizeSampleCount = false
+	if err := args.CheckSelectionWeights(); err == nil {
+		t.Errorf("Expected an error for 4 weights and 3 objectives")
+	}
+}
+
 func removeLogFile(t *testing.T, filePath string) {
 	// Remove the file
 	err := os.Remove(filePath)
@@ -0,0 +1,163 @@
+package fileio
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// names of the supported compressions
+const (
+	NoCompression   = "none"
+	GzipCompression = "gzip"
+	ZstdCompression = "zstd"
+)
+
+// compressionExtensions maps each compression to the extension of its files
+var compressionExtensions = map[string]string{
+	NoCompression:   "",
+	GzipCompression: ".gz",
+	ZstdCompression: ".zst",
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ValidateCompression returns an error for unknown compressions
+func ValidateCompression(compression string) error {
+	if _, ok := compressionExtensions[compression]; !ok {
+		return fmt.Errorf("unknown compression %q, valid values are %s, %s and %s", compression, NoCompression, GzipCompression, ZstdCompression)
+	}
+	return nil
+}
+
+// CompressedFileName adds the extension of the compression to the file name
+func CompressedFileName(fileName, compression string) string {
+	return fileName + compressionExtensions[compression]
+}
+
+// TrimCompressionExtension removes the extension of any supported compression from the file name
+func TrimCompressionExtension(fileName string) string {
+	for _, extension := range compressionExtensions {
+		if extension != "" && strings.HasSuffix(fileName, extension) {
+			return strings.TrimSuffix(fileName, extension)
+		}
+	}
+	return fileName
+}
+
+// ResolveCompressedFile returns the existing file for the given name, with or without the extension of any compression.
+// The name itself is returned if no such file exists.
+func ResolveCompressedFile(fileName string) string {
+	base := TrimCompressionExtension(fileName)
+	candidates := []string{fileName, base}
+	for _, compression := range []string{GzipCompression, ZstdCompression} {
+		candidates = append(candidates, CompressedFileName(base, compression))
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return fileName
+}
+
+// compressedWriter closes the compressor before the underlying file
+type compressedWriter struct {
+	io.WriteCloser
+	file io.Closer
+}
+
+func (w compressedWriter) Close() error {
+	err := w.WriteCloser.Close()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// CreateCompressedFile creates, or truncates, a file that is written with the given compression
+func CreateCompressedFile(fileName, compression string) (io.WriteCloser, error) {
+	return openCompressedFile(fileName, compression, os.O_TRUNC)
+}
+
+// AppendCompressedFile appends to a file with the given compression, every writer adds a separate compressed stream
+// to the file, which are read as a single stream
+func AppendCompressedFile(fileName, compression string) (io.WriteCloser, error) {
+	return openCompressedFile(fileName, compression, os.O_APPEND)
+}
+
+func openCompressedFile(fileName, compression string, flag int) (io.WriteCloser, error) {
+	if err := ValidateCompression(compression); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|flag, 0666)
+	if err != nil {
+		return nil, err
+	}
+	var writer io.WriteCloser
+	switch compression {
+	case GzipCompression:
+		writer = gzip.NewWriter(file)
+	case ZstdCompression:
+		writer, err = zstd.NewWriter(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+	default:
+		return file, nil
+	}
+	return compressedWriter{WriteCloser: writer, file: file}, nil
+}
+
+// decompressedReader closes the decompressor before the underlying file
+type decompressedReader struct {
+	io.Reader
+	close func()
+	file  io.Closer
+}
+
+func (r decompressedReader) Close() error {
+	if r.close != nil {
+		r.close()
+	}
+	return r.file.Close()
+}
+
+// OpenDecompressedFile opens a file for reading, gzip and zstd compressed files are detected by their content
+// and decompressed transparently
+func OpenDecompressedFile(fileName string) (io.ReadCloser, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	buffered := bufio.NewReader(file)
+	magic, _ := buffered.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return decompressedReader{Reader: reader, close: func() { reader.Close() }, file: file}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		reader, err := zstd.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return decompressedReader{Reader: reader, close: reader.Close, file: file}, nil
+	default:
+		return decompressedReader{Reader: buffered, file: file}, nil
+	}
+}
@@ -0,0 +1,64 @@
+package fileio
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendLinesToCompressedFile(t *testing.T) {
+	dir := filepath.Join("testresult", "AppendLinesToCompressedFile")
+	CreateEmptyDir(dir)
+	fw := newFileWriter()
+	for _, compression := range []string{NoCompression, GzipCompression, ZstdCompression} {
+		t.Run(compression, func(t *testing.T) {
+			fileName := CompressedFileName(filepath.Join(dir, "test.paths"), compression)
+			// every append adds a separate stream, which is read as a single stream
+			if err := fw.AppendLinesToCompressedFile(fileName, compression, []string{"line1", "line2", ""}); err != nil {
+				t.Fatalf("first append failed: %v", err)
+			}
+			if err := fw.AppendLinesToCompressedFile(fileName, compression, []string{"line3", ""}); err != nil {
+				t.Fatalf("second append failed: %v", err)
+			}
+			if resolved := ResolveCompressedFile(filepath.Join(dir, "test.paths")); resolved != fileName {
+				t.Errorf("expected %s to be resolved, got %s", fileName, resolved)
+			}
+			reader, err := OpenDecompressedFile(fileName)
+			if err != nil {
+				t.Fatalf("failed to open: %v", err)
+			}
+			defer reader.Close()
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if string(content) != "line1\nline2\nline3\n" {
+				t.Errorf("unexpected content %q", content)
+			}
+			CreateEmptyDir(dir)
+		})
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	for _, compression := range []string{NoCompression, GzipCompression, ZstdCompression} {
+		if err := ValidateCompression(compression); err != nil {
+			t.Errorf("expected %s to be valid: %v", compression, err)
+		}
+	}
+	if err := ValidateCompression("bzip2"); err == nil {
+		t.Errorf("expected bzip2 to be invalid")
+	}
+}
+
+func TestTrimCompressionExtension(t *testing.T) {
+	for fileName, expected := range map[string]string{
+		"a.paths":     "a.paths",
+		"a.paths.gz":  "a.paths",
+		"a.paths.zst": "a.paths",
+	} {
+		if trimmed := TrimCompressionExtension(fileName); trimmed != expected {
+			t.Errorf("expected %s, got %s", expected, trimmed)
+		}
+	}
+}
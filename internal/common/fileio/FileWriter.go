@@ -1,6 +1,7 @@
 package fileio
 
 import (
+	"io"
 	"log/slog"
 	"os"
 )
@@ -33,16 +34,38 @@ func (fw *FileWriter) writeLinesToFile(filePath string, flag int, lineSets ...[]
 			fw.Error("Failed to close file", "file", filePath, "error", err)
 		}
 	}()
-	// write all lines from all line sets to the file
+	return writeLines(file, lineSets...)
+}
+
+// AppendLinesToCompressedFile appends the lines to a file with the given compression
+func (fw *FileWriter) AppendLinesToCompressedFile(filePath, compression string, lineSets ...[]string) (err error) {
+	writer, err := AppendCompressedFile(filePath, compression)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cerr := writer.Close()
+		if err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fw.Error("Failed to close file", "file", filePath, "error", err)
+		}
+	}()
+	return writeLines(writer, lineSets...)
+}
+
+// writeLines writes all lines from all line sets to the writer
+func writeLines(writer io.Writer, lineSets ...[]string) error {
 	for i, lines := range lineSets {
 		for j, line := range lines {
 			var err error
 			if i == len(lineSets)-1 && j == len(lines)-1 {
 				// no newline after the last line
-				_, err = file.WriteString(line)
+				_, err = io.WriteString(writer, line)
 			} else {
 				// append newline
-				_, err = file.WriteString(line + "\n")
+				_, err = io.WriteString(writer, line+"\n")
 			}
 			if err != nil {
 				return err
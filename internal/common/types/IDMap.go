@@ -1,6 +1,8 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 )
@@ -108,6 +110,23 @@ func (im *IDMap[ID, Name]) GetMappedName(id ID, mapping TranslationMap[Name]) Na
 	return name
 }
 
+// MaxID returns the ID that is assigned to the next new name, all known IDs are smaller
+func (im *IDMap[ID, Name]) MaxID() ID {
+	return im.maxID
+}
+
+// Hash returns a hash of the entries with an ID below limit, in the order of their IDs
+// since IDs are assigned sequentially, the hash of an index does not change when names are added
+func (im *IDMap[ID, Name]) Hash(limit ID) string {
+	hash := sha256.New()
+	for id := range limit {
+		if name, knownName := im.idToName[id]; knownName {
+			fmt.Fprintf(hash, "%d\t%s\n", id, name)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 type fileWriter interface {
 	WriteLinesToNewFile(string, ...[]string) error
 }
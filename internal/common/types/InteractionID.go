@@ -3,8 +3,12 @@ package types
 import (
 	"fmt"
 	"strconv"
+	"sync"
 )
 
+// InteractionID identifies an interaction by its genes and its interaction type. The gene IDs are packed into 29 bits
+// each and the interaction type into 6 bits. With the wide encoding, interactions that do not fit are stored in a
+// table, and their ID refers to their entry in the table.
 type InteractionID uint64
 
 const (
@@ -12,8 +16,71 @@ const (
 	interactionTypeBits = 6
 	maxGeneID           = (1 << geneIDBits) - 1
 	maxInteractionType  = (1 << interactionTypeBits) - 1
+	// wideMarker is the interaction type of the IDs that refer to the table of wide interactions
+	wideMarker = maxInteractionType
 )
 
+// InteractionIDEncoding determines how interactions are encoded into an InteractionID
+type InteractionIDEncoding int
+
+const (
+	// CompactInteractionIDs packs every interaction into its ID, which limits the gene IDs and the interaction types
+	CompactInteractionIDs InteractionIDEncoding = iota
+	// WideInteractionIDs packs the interactions that fit into their ID, and stores the others in a table. The IDs in
+	// the table depend on the order in which interactions are encountered.
+	WideInteractionIDs
+)
+
+// InteractionIDEncodings are the names of the interaction ID encodings
+var InteractionIDEncodings = map[string]InteractionIDEncoding{
+	"compact": CompactInteractionIDs,
+	"wide":    WideInteractionIDs,
+}
+
+// wideInteraction is an interaction that does not fit in the compact encoding
+type wideInteraction struct {
+	from, to GeneID
+	typ      InteractionTypeID
+}
+
+var (
+	interactionIDEncoding = CompactInteractionIDs
+	wideInteractions      = struct {
+		sync.RWMutex
+		ids          map[wideInteraction]InteractionID
+		interactions []wideInteraction
+	}{ids: make(map[wideInteraction]InteractionID)}
+)
+
+// SetInteractionIDEncoding sets the encoding of the interaction IDs, which should happen before any ID is created
+func SetInteractionIDEncoding(encoding InteractionIDEncoding) {
+	wideInteractions.Lock()
+	defer wideInteractions.Unlock()
+	interactionIDEncoding = encoding
+	wideInteractions.ids = make(map[wideInteraction]InteractionID)
+	wideInteractions.interactions = nil
+}
+
+// ParseInteractionIDEncoding returns the interaction ID encoding with the given name
+func ParseInteractionIDEncoding(name string) (InteractionIDEncoding, error) {
+	encoding, ok := InteractionIDEncodings[name]
+	if !ok {
+		return encoding, fmt.Errorf("unknown interaction ID encoding %q, expected compact or wide", name)
+	}
+	return encoding, nil
+}
+
+// ValidateInteractionType returns an error if the interaction type does not fit in the interaction ID encoding
+func ValidateInteractionType(typ InteractionTypeID) error {
+	if interactionIDEncoding == CompactInteractionIDs && typ > maxInteractionType {
+		return fmt.Errorf(
+			"interaction type %d exceeds the compact interaction ID encoding, which supports at most %d interaction types, use --interaction-id-encoding wide",
+			typ, maxInteractionType+1,
+		)
+	}
+	return nil
+}
+
 func (i InteractionID) Type() InteractionTypeID {
 	return IDToType(i)
 }
@@ -63,13 +130,55 @@ func (i InteractionID) StringWithProbability(p float64) string {
 
 // FromToTypeToID is a function that returns the ID of the interaction from the given gene IDs and interaction type ID
 func FromToTypeToID(from, to GeneID, typ InteractionTypeID) InteractionID {
-	return InteractionID(uint64(typ)<<(2*geneIDBits) | uint64(from)<<geneIDBits | uint64(to))
+	if from <= maxGeneID && to <= maxGeneID && typ <= maxInteractionType {
+		if interactionIDEncoding == CompactInteractionIDs || typ != wideMarker {
+			return InteractionID(uint64(typ)<<(2*geneIDBits) | uint64(from)<<geneIDBits | uint64(to))
+		}
+	}
+	if interactionIDEncoding == CompactInteractionIDs {
+		panic(fmt.Sprintf(
+			"interaction %d->%d of type %d exceeds the compact interaction ID encoding, which supports gene IDs up to %d and interaction types up to %d, use --interaction-id-encoding wide",
+			from, to, typ, maxGeneID, maxInteractionType,
+		))
+	}
+	return wideInteractionID(wideInteraction{from, to, typ})
+}
+
+// wideInteractionID returns the ID of an interaction in the table of wide interactions, adding it if it is new
+func wideInteractionID(interaction wideInteraction) InteractionID {
+	wideInteractions.RLock()
+	id, ok := wideInteractions.ids[interaction]
+	wideInteractions.RUnlock()
+	if ok {
+		return id
+	}
+	wideInteractions.Lock()
+	defer wideInteractions.Unlock()
+	if id, ok := wideInteractions.ids[interaction]; ok {
+		return id
+	}
+	id = InteractionID(uint64(wideMarker)<<(2*geneIDBits) | uint64(len(wideInteractions.interactions)))
+	wideInteractions.ids[interaction] = id
+	wideInteractions.interactions = append(wideInteractions.interactions, interaction)
+	return id
+}
+
+// isWide returns whether the ID refers to the table of wide interactions
+func (i InteractionID) isWide() bool {
+	return interactionIDEncoding == WideInteractionIDs && (i>>(2*geneIDBits))&maxInteractionType == wideMarker
+}
+
+// wide returns the interaction in the table of wide interactions that the ID refers to
+func (i InteractionID) wide() wideInteraction {
+	wideInteractions.RLock()
+	defer wideInteractions.RUnlock()
+	return wideInteractions.interactions[i&(1<<(2*geneIDBits)-1)]
 }
 
 // FromToToID is a function that returns the ID of the interaction from the given gene IDs
 // TODO: this should be removed, use the version with type instead
 func FromToToID(from, to GeneID) InteractionID {
-	return InteractionID(uint64(from<<geneIDBits) | uint64(to))
+	return FromToTypeToID(from, to, 0)
 }
 
 // IDToFromTo is a function that returns the gene IDs of the interaction from the given ID
@@ -79,15 +188,24 @@ func IDToFromTo(id InteractionID) (GeneID, GeneID) {
 
 // IDToFrom is a function that returns the gene ID of the start point of the interaction with the given ID
 func IDToFrom(id InteractionID) GeneID {
+	if id.isWide() {
+		return id.wide().from
+	}
 	return GeneID((id >> geneIDBits) & maxGeneID)
 }
 
 // IDToTo is a function that returns the gene ID of the end point of the interaction with the given ID
 func IDToTo(id InteractionID) GeneID {
+	if id.isWide() {
+		return id.wide().to
+	}
 	return GeneID(id & maxGeneID)
 }
 
 // IDToType is a function that returns the interaction type of the interaction with the given ID
 func IDToType(id InteractionID) InteractionTypeID {
+	if id.isWide() {
+		return id.wide().typ
+	}
 	return InteractionTypeID((id >> (2 * geneIDBits)) & maxInteractionType)
 }
@@ -96,3 +96,73 @@ func TestInteractionID_StringMinimal(t *testing.T) {
 		t.Errorf("expected StringMinimal: %s, got: %s", expected, interactionID.StringMinimal())
 	}
 }
+
+// TestCompactEncodingLimits tests that interactions exceeding the compact encoding are refused
+func TestCompactEncodingLimits(t *testing.T) {
+	SetInteractionIDEncoding(CompactInteractionIDs)
+	if err := ValidateInteractionType(maxInteractionType); err != nil {
+		t.Errorf("expected type %d to be valid, got %v", maxInteractionType, err)
+	}
+	if err := ValidateInteractionType(maxInteractionType + 1); err == nil {
+		t.Errorf("expected type %d to be invalid", maxInteractionType+1)
+	}
+	for _, c := range []struct {
+		from, to GeneID
+		typ      InteractionTypeID
+	}{
+		{maxGeneID + 1, 0, 0},
+		{0, maxGeneID + 1, 0},
+		{0, 0, maxInteractionType + 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected interaction %d->%d of type %d to exceed the compact encoding", c.from, c.to, c.typ)
+				}
+			}()
+			FromToTypeToID(c.from, c.to, c.typ)
+		}()
+	}
+}
+
+// TestWideEncoding tests that the wide encoding round-trips interactions beyond the compact limits
+func TestWideEncoding(t *testing.T) {
+	SetInteractionIDEncoding(WideInteractionIDs)
+	defer SetInteractionIDEncoding(CompactInteractionIDs)
+	if err := ValidateInteractionType(1 << 20); err != nil {
+		t.Errorf("expected any type to be valid, got %v", err)
+	}
+	cases := []struct {
+		from, to GeneID
+		typ      InteractionTypeID
+	}{
+		{1, 2, 3},                              // compact
+		{1, 2, wideMarker},                     // the marker type
+		{1, 2, 1000},                           // wide type
+		{maxGeneID + 1, 2, 3},                  // wide from
+		{1, 1 << 40, 3},                        // wide to
+		{1 << 40, 1 << 41, 1 << 30},            // all wide
+		{maxGeneID, maxGeneID, wideMarker - 1}, // compact max
+	}
+	ids := make(map[InteractionID]struct{})
+	for _, c := range cases {
+		id := FromToTypeToID(c.from, c.to, c.typ)
+		if id.From() != c.from || id.To() != c.to || id.Type() != c.typ {
+			t.Errorf("round-trip failed: expected %d->%d of type %d, got %d->%d of type %d", c.from, c.to, c.typ, id.From(), id.To(), id.Type())
+		}
+		if again := FromToTypeToID(c.from, c.to, c.typ); again != id {
+			t.Errorf("expected the same ID for %d->%d of type %d, got %d and %d", c.from, c.to, c.typ, id, again)
+		}
+		if reverse := id.Reverse(); reverse.From() != c.to || reverse.To() != c.from || reverse.Type() != c.typ {
+			t.Errorf("reverse failed for %d->%d of type %d", c.from, c.to, c.typ)
+		}
+		ids[id] = struct{}{}
+	}
+	if len(ids) != len(cases) {
+		t.Errorf("expected %d distinct IDs, got %d", len(cases), len(ids))
+	}
+	// compact interactions keep their compact ID
+	if id := FromToTypeToID(1, 2, 3); id != InteractionID(3<<58|1<<29|2) {
+		t.Errorf("expected the compact ID of a fitting interaction, got %d", id)
+	}
+}
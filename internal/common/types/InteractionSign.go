@@ -0,0 +1,49 @@
+package types
+
+import "strings"
+
+// InteractionSign is the effect of an interaction on its target gene
+type InteractionSign int8
+
+const (
+	// Unsigned interactions have an unknown effect
+	Unsigned   InteractionSign = 0
+	Activation InteractionSign = 1
+	Inhibition InteractionSign = -1
+)
+
+// ParseInteractionSign parses the sign column of a network file, ok is false if the value is not a sign
+func ParseInteractionSign(value string) (sign InteractionSign, ok bool) {
+	switch strings.ToLower(value) {
+	case "activation", "activates", "activating", "+":
+		return Activation, true
+	case "inhibition", "inhibits", "inhibiting", "-":
+		return Inhibition, true
+	case "unsigned", "unknown":
+		return Unsigned, true
+	}
+	return Unsigned, false
+}
+
+// SignOf returns the sign of a value, such as a log fold change
+func SignOf(value float64) InteractionSign {
+	switch {
+	case value > 0:
+		return Activation
+	case value < 0:
+		return Inhibition
+	default:
+		return Unsigned
+	}
+}
+
+func (sign InteractionSign) String() string {
+	switch sign {
+	case Activation:
+		return "activation"
+	case Inhibition:
+		return "inhibition"
+	default:
+		return "unsigned"
+	}
+}
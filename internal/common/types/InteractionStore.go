@@ -3,11 +3,12 @@ package types
 import "fmt"
 
 type InteractionStore struct {
-	outgoing         map[GeneID]InteractionIDSet // outgoing interactions
-	incoming         map[GeneID]InteractionIDSet // incoming interactions
-	interactionCount int                         // Number of interactions
-	interactionTypes *InteractionTypeIDMap       // Interaction types
-	isRegulatory     IsRegulatory                // Regulatory interactions
+	outgoing         map[GeneID]InteractionIDSet       // outgoing interactions
+	incoming         map[GeneID]InteractionIDSet       // incoming interactions
+	interactionCount int                               // Number of interactions
+	interactionTypes *InteractionTypeIDMap             // Interaction types
+	isRegulatory     IsRegulatory                      // Regulatory interactions
+	signs            map[InteractionID]InteractionSign // Signed interactions
 }
 
 // NewInteractionStore creates a new InteractionStore
@@ -18,6 +19,7 @@ func NewInteractionStore() *InteractionStore {
 		interactionCount: 0,
 		interactionTypes: NewInteractionTypeIDMap(),
 		isRegulatory:     NewIsRegulatory(),
+		signs:            make(map[InteractionID]InteractionSign),
 	}
 }
 
@@ -49,6 +51,24 @@ func (s *InteractionStore) IsRegulatoryInteraction(interactionID InteractionID)
 	return s.isRegulatory[interactionID.Type()]
 }
 
+// SetSign sets the sign of the interaction, unsigned interactions are not stored
+// the store is only written when the sign changes, such that reading a network again does not modify it
+func (s *InteractionStore) SetSign(interactionID InteractionID, sign InteractionSign) {
+	if s.signs[interactionID] == sign {
+		return
+	}
+	if sign == Unsigned {
+		delete(s.signs, interactionID)
+		return
+	}
+	s.signs[interactionID] = sign
+}
+
+// Sign returns the sign of the interaction, which is unsigned if it is unknown
+func (s *InteractionStore) Sign(interactionID InteractionID) InteractionSign {
+	return s.signs[interactionID]
+}
+
 func (s *InteractionStore) InteractionTypeStringList() []string {
 	lines := make([]string, 0, len(s.interactionTypes.IdToName()))
 	for interactionTypeID, interactionTypeName := range s.interactionTypes.IdToName() {
@@ -103,15 +123,14 @@ func (s *InteractionStore) AddInteraction(interaction InteractionID) {
 	s.interactionCount++
 }
 
-// RemoveInteraction deletes an edge
+// RemoveInteraction deletes an edge, if it exists
 func (s *InteractionStore) RemoveInteraction(interaction InteractionID) {
-	from, to := interaction.FromTo()
-	if _, exists := s.outgoing[from]; exists {
-		delete(s.outgoing[from], interaction)
-	}
-	if _, exists := s.incoming[to]; exists {
-		delete(s.incoming[to], interaction)
+	if !s.Has(interaction) {
+		return
 	}
+	from, to := interaction.FromTo()
+	delete(s.outgoing[from], interaction)
+	delete(s.incoming[to], interaction)
 	s.interactionCount--
 }
 
@@ -146,6 +165,38 @@ func (s *InteractionStore) RemoveNode(id GeneID) {
 	}
 }
 
+// WithoutGenes returns a copy of the store without the genes and their interactions. The copy shares the interaction
+// type map with the store, but the store itself is not modified, such that it can be read concurrently.
+func (s *InteractionStore) WithoutGenes(genes GeneSet) *InteractionStore {
+	store := &InteractionStore{
+		outgoing:         make(map[GeneID]InteractionIDSet, len(s.outgoing)),
+		incoming:         make(map[GeneID]InteractionIDSet, len(s.incoming)),
+		interactionCount: 0,
+		interactionTypes: s.interactionTypes,
+		isRegulatory:     NewIsRegulatory(),
+		signs:            make(map[InteractionID]InteractionSign),
+	}
+	for id, isRegulatory := range s.isRegulatory {
+		store.isRegulatory[id] = isRegulatory
+	}
+	for gene, interactions := range s.outgoing {
+		if _, removed := genes[gene]; removed {
+			continue
+		}
+		store.AddNode(gene)
+		for interaction := range interactions {
+			if _, removed := genes[interaction.To()]; removed {
+				continue
+			}
+			store.AddInteraction(interaction)
+			if sign, ok := s.signs[interaction]; ok {
+				store.signs[interaction] = sign
+			}
+		}
+	}
+	return store
+}
+
 // Has returns true if the graph contains the given interaction
 func (s *InteractionStore) Has(interaction InteractionID) bool {
 	from := interaction.From()
@@ -184,7 +235,13 @@ func (s *InteractionStore) SanityCheck() {
 
 func (s *InteractionStore) AddInteractionType(interactionTypeString string, isRegulatory bool) {
 	id := s.interactionTypes.SetName(interactionTypeString)
-	s.isRegulatory[id] = isRegulatory
+	if err := ValidateInteractionType(id); err != nil {
+		panic(fmt.Sprintf("interaction type %s: %v", interactionTypeString, err))
+	}
+	// the store is only written for new interaction types, such that reading a network again does not modify it
+	if known, ok := s.isRegulatory[id]; !ok || known != isRegulatory {
+		s.isRegulatory[id] = isRegulatory
+	}
 }
 
 func (s *InteractionStore) GetInteractionTypeID(interactionTypeString string) InteractionTypeID {
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+)
+
+func createTestInteractionStore() *InteractionStore {
+	store := NewInteractionStore()
+	store.AddInteraction(FromToToID(1, 2))
+	store.AddInteraction(FromToToID(2, 3))
+	store.AddInteraction(FromToToID(3, 1))
+	store.SetSign(FromToToID(2, 3), Inhibition)
+	return store
+}
+
+func TestInteractionStore_RemoveInteraction(t *testing.T) {
+	store := createTestInteractionStore()
+	store.RemoveInteraction(FromToToID(1, 3))
+	if store.InteractionCount() != 3 {
+		t.Errorf("Expected 3 interactions after removing a missing interaction, got %d", store.InteractionCount())
+	}
+	store.RemoveInteraction(FromToToID(1, 2))
+	store.RemoveInteraction(FromToToID(1, 2))
+	if store.InteractionCount() != 2 {
+		t.Errorf("Expected 2 interactions, got %d", store.InteractionCount())
+	}
+	if store.Has(FromToToID(1, 2)) {
+		t.Errorf("Expected interaction 1->2 to be removed")
+	}
+	store.SanityCheck()
+}
+
+func TestInteractionStore_WithoutGenes(t *testing.T) {
+	store := createTestInteractionStore()
+	copied := store.WithoutGenes(GeneSet{1: {}})
+	copied.SanityCheck()
+	if copied.HasNode(1) {
+		t.Errorf("Expected gene 1 to be removed from the copy")
+	}
+	if copied.InteractionCount() != 1 || !copied.Has(FromToToID(2, 3)) {
+		t.Errorf("Expected only interaction 2->3 in the copy, got %d interactions", copied.InteractionCount())
+	}
+	if copied.Sign(FromToToID(2, 3)) != Inhibition {
+		t.Errorf("Expected the sign of 2->3 to be copied")
+	}
+	if !store.HasNode(1) || store.InteractionCount() != 3 {
+		t.Errorf("Expected the original store to be unchanged, got %d interactions", store.InteractionCount())
+	}
+}
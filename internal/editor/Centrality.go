@@ -0,0 +1,212 @@
+package editor
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// hub centralities of the sigmoidal topology weighting
+const (
+	CentralityOutDegree   = "out-degree"
+	CentralityInDegree    = "in-degree"
+	CentralityDegree      = "degree"
+	CentralityPageRank    = "pagerank"
+	CentralityBetweenness = "betweenness"
+)
+
+// Centralities are the valid hub centralities
+var Centralities = []string{
+	CentralityOutDegree,
+	CentralityInDegree,
+	CentralityDegree,
+	CentralityPageRank,
+	CentralityBetweenness,
+}
+
+// parameters of the pagerank and the sampled betweenness centralities
+const (
+	pageRankDamping    = 0.85
+	betweennessSamples = 256
+	betweennessSeed    = 1
+)
+
+// Centrality computes the centrality of every gene of the network. The degree centralities count interactions, the
+// pagerank and betweenness centralities are computed on the directed gene graph of the network.
+func Centrality(network *graph.Network, centrality string) (map[types.GeneID]float64, error) {
+	scores := make(map[types.GeneID]float64, len(network.Genes()))
+	switch centrality {
+	case "", CentralityOutDegree:
+		for gene := range network.Genes() {
+			scores[gene] = float64(network.OutDegree(gene))
+		}
+	case CentralityInDegree:
+		for gene := range network.Genes() {
+			scores[gene] = float64(network.InDegree(gene))
+		}
+	case CentralityDegree:
+		for gene := range network.Genes() {
+			scores[gene] = float64(network.Degree(gene))
+		}
+	case CentralityPageRank:
+		graph := newGeneGraph(network)
+		for i, score := range graph.pageRank() {
+			scores[graph.genes[i]] = score
+		}
+	case CentralityBetweenness:
+		graph := newGeneGraph(network)
+		for i, score := range graph.betweenness(betweennessSamples, rand.New(rand.NewSource(betweennessSeed))) {
+			scores[graph.genes[i]] = score
+		}
+	default:
+		return nil, fmt.Errorf("unknown hub centrality %q, expected one of %v", centrality, Centralities)
+	}
+	return scores, nil
+}
+
+// isDiscreteCentrality returns whether the centrality counts interactions
+func isDiscreteCentrality(centrality string) bool {
+	return centrality != CentralityPageRank && centrality != CentralityBetweenness
+}
+
+// geneGraph is the directed graph of the genes of a network, without parallel interactions
+type geneGraph struct {
+	genes     []types.GeneID
+	neighbors [][]int
+}
+
+func newGeneGraph(network *graph.Network) geneGraph {
+	genes := make([]types.GeneID, 0, len(network.Genes()))
+	for gene := range network.Genes() {
+		genes = append(genes, gene)
+	}
+	slices.Sort(genes)
+	index := make(map[types.GeneID]int, len(genes))
+	for i, gene := range genes {
+		index[gene] = i
+	}
+	edges := make([]map[int]struct{}, len(genes))
+	for i := range edges {
+		edges[i] = make(map[int]struct{})
+	}
+	for id := range *network.Probabilities() {
+		from, fromOk := index[id.From()]
+		to, toOk := index[id.To()]
+		if fromOk && toOk && from != to {
+			edges[from][to] = struct{}{}
+		}
+	}
+	neighbors := make([][]int, len(genes))
+	for i, row := range edges {
+		for j := range row {
+			neighbors[i] = append(neighbors[i], j)
+		}
+		slices.Sort(neighbors[i])
+	}
+	return geneGraph{genes, neighbors}
+}
+
+// pageRank computes the pagerank of the genes, the rank of genes without outgoing interactions is spread over all genes
+func (g geneGraph) pageRank() []float64 {
+	n := len(g.genes)
+	ranks := make([]float64, n)
+	if n == 0 {
+		return ranks
+	}
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for range propagationMaxIterations {
+		dangling := 0.0
+		for i, neighbors := range g.neighbors {
+			if len(neighbors) == 0 {
+				dangling += ranks[i]
+			}
+		}
+		base := (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, neighbors := range g.neighbors {
+			for _, j := range neighbors {
+				next[j] += pageRankDamping * ranks[i] / float64(len(neighbors))
+			}
+		}
+		change := 0.0
+		for i := range ranks {
+			change += math.Abs(next[i] - ranks[i])
+		}
+		ranks, next = next, ranks
+		if change < propagationTolerance {
+			break
+		}
+	}
+	return ranks
+}
+
+// betweenness approximates the betweenness of the genes with Brandes' algorithm on a random sample of source genes,
+// the sampled dependencies are extrapolated to all sources
+func (g geneGraph) betweenness(samples int, rng *rand.Rand) []float64 {
+	n := len(g.genes)
+	centrality := make([]float64, n)
+	sources := rng.Perm(n)
+	if samples < n {
+		sources = sources[:samples]
+	}
+	distance := make([]int, n)
+	paths := make([]float64, n)
+	dependency := make([]float64, n)
+	predecessors := make([][]int, n)
+	order := make([]int, 0, n)
+	queue := make([]int, 0, n)
+	for _, source := range sources {
+		for i := range n {
+			distance[i] = -1
+			paths[i] = 0
+			dependency[i] = 0
+			predecessors[i] = predecessors[i][:0]
+		}
+		order = order[:0]
+		queue = append(queue[:0], source)
+		distance[source] = 0
+		paths[source] = 1
+		// breadth-first search counting the shortest paths from the source
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			order = append(order, v)
+			for _, w := range g.neighbors[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					paths[w] += paths[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+		// accumulate the dependencies in order of decreasing distance
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range predecessors[w] {
+				dependency[v] += paths[v] / paths[w] * (1 + dependency[w])
+			}
+			if w != source {
+				centrality[w] += dependency[w]
+			}
+		}
+	}
+	if len(sources) > 0 {
+		scale := float64(n) / float64(len(sources))
+		for i := range centrality {
+			centrality[i] *= scale
+		}
+	}
+	return centrality
+}
@@ -0,0 +1,76 @@
+package editor_test
+
+import (
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+func TestCentrality(t *testing.T) {
+	outputDir := "testresult/Centrality"
+	fileio.CreateEmptyDir(outputDir)
+	networkFile := filepath.Join(outputDir, "bridge.sif")
+	// two stars around h1 and h2, connected by the bridge b
+	network := "h1\tpp\ta1\nh1\tpp\ta2\nh1\tpp\ta3\nh1\tpp\tb\nb\tpp\th2\nh2\tpp\tc1\nh2\tpp\tc2\n"
+	if err := os.WriteFile(networkFile, []byte(network), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	arguments.GlobalInteractionStore = types.NewInteractionStore()
+	gim := types.NewGeneIDMap()
+	nwr := readers.NewInitialNetworkReader(slog.Default(), gim).WithFormat(readers.NetworkFormatSIF, "")
+	graph := nwr.NewNetworkFromFile(networkFile, false, true)
+	centrality := func(method, gene string) float64 {
+		scores, err := editor.Centrality(graph, method)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", method, err)
+		}
+		return scores[gim.GetIDFromName(types.GeneName(gene))]
+	}
+
+	// the interactions are undirected, so every degree centrality is consistent with the out-degree
+	if centrality(editor.CentralityOutDegree, "h1") != 4 || centrality(editor.CentralityInDegree, "h1") != 4 || centrality(editor.CentralityDegree, "h1") != 8 {
+		t.Errorf("unexpected degrees of h1: %f %f %f", centrality(editor.CentralityOutDegree, "h1"), centrality(editor.CentralityInDegree, "h1"), centrality(editor.CentralityDegree, "h1"))
+	}
+	// the bridge has a low degree, but lies on all shortest paths between the stars
+	if betweenness := centrality(editor.CentralityBetweenness, "b"); betweenness != 24 {
+		t.Errorf("expected the bridge to have betweenness 24, got %f", betweenness)
+	}
+	if betweenness := centrality(editor.CentralityBetweenness, "a1"); betweenness != 0 {
+		t.Errorf("expected a leaf to have betweenness 0, got %f", betweenness)
+	}
+	// the pageranks sum to one and the hubs have the highest rank
+	total := 0.0
+	for _, gene := range []string{"h1", "h2", "a1", "a2", "a3", "b", "c1", "c2"} {
+		total += centrality(editor.CentralityPageRank, gene)
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("expected the pageranks to sum to 1, got %f", total)
+	}
+	if !(centrality(editor.CentralityPageRank, "h1") > centrality(editor.CentralityPageRank, "b") &&
+		centrality(editor.CentralityPageRank, "b") > centrality(editor.CentralityPageRank, "a1")) {
+		t.Errorf("expected the pagerank to decrease from hub to bridge to leaf")
+	}
+	if _, err := editor.Centrality(graph, "closeness"); err == nil {
+		t.Errorf("expected an error for an unknown centrality")
+	}
+
+	// the sigmoid penalizes the most central genes
+	for _, method := range editor.Centralities {
+		weight, err := editor.NewSigmoidalCentralityWeight(graph, method, 0.01)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", method, err)
+		}
+		score := func(gene string) float64 { return weight.Score(gim.GetIDFromName(types.GeneName(gene))) }
+		if score("h1") > score("a1") {
+			t.Errorf("%s: expected the hub to score at most the leaf, got %f and %f", method, score("h1"), score("a1"))
+		}
+	}
+}
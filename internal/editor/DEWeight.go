@@ -64,3 +64,24 @@ func NewZscoreExpressionWeight(logger *slog.Logger, expressionData map[types.Gen
 		defaultProbability,
 	}
 }
+
+// NewActivityWeight uses gene activities, e.g. the fraction of samples in which a gene is expressed in a tissue,
+// directly as gene probabilities. Activities outside [0, 1] are clamped.
+func NewActivityWeight(logger *slog.Logger, activityData map[types.GeneID]float64, defaultProbability float64) ExpressionWeight {
+	scoreMap := make(map[types.GeneID]float64)
+	clamped := 0
+	for gene, activity := range activityData {
+		if activity < 0 || activity > 1 {
+			clamped++
+		}
+		scoreMap[gene] = min(max(activity, 0), 1)
+	}
+	if clamped > 0 {
+		logger.Warn("Gene activities outside [0, 1] were clamped", "count", clamped)
+	}
+	return ExpressionWeight{
+		logger,
+		scoreMap,
+		defaultProbability,
+	}
+}
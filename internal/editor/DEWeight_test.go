@@ -58,3 +58,15 @@ func TestNewZscoreExpressionWeight(t *testing.T) {
 		})
 	}
 }
+
+func TestNewActivityWeight(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	data := map[types.GeneID]float64{1: -0.5, 2: 0.25, 3: 1.5}
+	got := NewActivityWeight(logger, data, 0.1)
+	want := map[types.GeneID]float64{1: 0, 2: 0.25, 3: 1, 4: 0.1}
+	for gene, score := range want {
+		if got.Score(gene) != score {
+			t.Errorf("gene %d: expected score %f, got %f", gene, score, got.Score(gene))
+		}
+	}
+}
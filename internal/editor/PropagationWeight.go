@@ -0,0 +1,170 @@
+package editor
+
+import (
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// topology weighting methods
+const (
+	TopologyWeightingDegree = "degree"
+	TopologyWeightingRWR    = "rwr"
+	TopologyWeightingHeat   = "heat"
+)
+
+// propagation stops when the L1 change of the scores drops below propagationTolerance
+const (
+	propagationTolerance     = 1e-10
+	propagationMaxIterations = 1000
+)
+
+// PropagationWeight scores the genes by diffusing seed scores over the network
+type PropagationWeight struct {
+	scoreMap map[types.GeneID]float64
+}
+
+func (p PropagationWeight) Score(gene types.GeneID) float64 {
+	return p.scoreMap[gene]
+}
+
+// transitionMatrix is the sparse column-normalized adjacency matrix of the network, in which the interactions are
+// undirected and weighted with their probability
+type transitionMatrix struct {
+	genes     []types.GeneID
+	index     map[types.GeneID]int
+	neighbors [][]int
+	weights   [][]float64
+}
+
+func newTransitionMatrix(network *graph.Network) transitionMatrix {
+	genes := make([]types.GeneID, 0, len(network.Genes()))
+	for gene := range network.Genes() {
+		genes = append(genes, gene)
+	}
+	slices.Sort(genes)
+	index := make(map[types.GeneID]int, len(genes))
+	for i, gene := range genes {
+		index[gene] = i
+	}
+	// the weight of an undirected edge is the highest probability of the interactions between its genes
+	edges := make([]map[int]float64, len(genes))
+	for i := range edges {
+		edges[i] = make(map[int]float64)
+	}
+	for id := range *network.Probabilities() {
+		from, to := index[id.From()], index[id.To()]
+		probability := network.Probabilities().GetProbability(id)
+		edges[from][to] = math.Max(edges[from][to], probability)
+		edges[to][from] = math.Max(edges[to][from], probability)
+	}
+	matrix := transitionMatrix{
+		genes:     genes,
+		index:     index,
+		neighbors: make([][]int, len(genes)),
+		weights:   make([][]float64, len(genes)),
+	}
+	for i, row := range edges {
+		degree := 0.0
+		for _, weight := range row {
+			degree += weight
+		}
+		if degree == 0 {
+			continue
+		}
+		for j, weight := range row {
+			matrix.neighbors[i] = append(matrix.neighbors[i], j)
+			matrix.weights[i] = append(matrix.weights[i], weight/degree)
+		}
+	}
+	return matrix
+}
+
+// mulVec sets dst to the product of the transition matrix and x
+func (matrix transitionMatrix) mulVec(dst, x []float64) {
+	clear(dst)
+	for i, neighbors := range matrix.neighbors {
+		if x[i] == 0 {
+			continue
+		}
+		for k, j := range neighbors {
+			dst[j] += matrix.weights[i][k] * x[i]
+		}
+	}
+}
+
+// seedVector distributes the absolute seed scores of the genes in the network over a vector that sums to one
+func (matrix transitionMatrix) seedVector(seeds map[types.GeneID]float64) []float64 {
+	vector := make([]float64, len(matrix.genes))
+	for gene, score := range seeds {
+		if i, ok := matrix.index[gene]; ok {
+			vector[i] = math.Abs(score)
+		}
+	}
+	if sum := floats.Sum(vector); sum > 0 {
+		floats.Scale(1/sum, vector)
+	}
+	return vector
+}
+
+// scoreMap scales the propagated scores to [cutoff, 1]
+func (matrix transitionMatrix) scoreMap(scores []float64, cutoff float64) map[types.GeneID]float64 {
+	scoreMap := make(map[types.GeneID]float64, len(matrix.genes))
+	maximum := 0.0
+	if len(scores) > 0 {
+		maximum = floats.Max(scores)
+	}
+	for i, gene := range matrix.genes {
+		score := 0.0
+		if maximum > 0 {
+			score = scores[i] / maximum
+		}
+		scoreMap[gene] = math.Max(cutoff, score)
+	}
+	return scoreMap
+}
+
+// NewRandomWalkWeight diffuses the seed scores with a random walk with restart, which restarts at the seeds with
+// probability restart in every step
+func NewRandomWalkWeight(network *graph.Network, seeds map[types.GeneID]float64, restart, cutoff float64) PropagationWeight {
+	matrix := newTransitionMatrix(network)
+	start := matrix.seedVector(seeds)
+	scores := slices.Clone(start)
+	next := make([]float64, len(scores))
+	for range propagationMaxIterations {
+		// next = (1 - restart) * W * scores + restart * start
+		matrix.mulVec(next, scores)
+		floats.Scale(1-restart, next)
+		floats.AddScaled(next, restart, start)
+		change := floats.Distance(next, scores, 1)
+		scores, next = next, scores
+		if change < propagationTolerance {
+			break
+		}
+	}
+	return PropagationWeight{matrix.scoreMap(scores, cutoff)}
+}
+
+// NewHeatKernelWeight diffuses the seed scores with the heat kernel exp(-time * (I - W)), which is computed as the
+// truncated series exp(-time) * sum_k time^k / k! * W^k
+func NewHeatKernelWeight(network *graph.Network, seeds map[types.GeneID]float64, time, cutoff float64) PropagationWeight {
+	matrix := newTransitionMatrix(network)
+	term := matrix.seedVector(seeds)
+	scores := slices.Clone(term)
+	next := make([]float64, len(term))
+	for k := 1; k <= propagationMaxIterations; k++ {
+		matrix.mulVec(next, term)
+		floats.Scale(time/float64(k), next)
+		floats.Add(scores, next)
+		term, next = next, term
+		if floats.Norm(term, 1) < propagationTolerance {
+			break
+		}
+	}
+	// the factor exp(-time) is omitted, since the scores are scaled to their maximum
+	return PropagationWeight{matrix.scoreMap(scores, cutoff)}
+}
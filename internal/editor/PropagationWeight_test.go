@@ -0,0 +1,57 @@
+package editor_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+func TestPropagationWeight(t *testing.T) {
+	outputDir := "testresult/PropagationWeight"
+	fileio.CreateEmptyDir(outputDir)
+	networkFile := filepath.Join(outputDir, "chain.sif")
+	// a chain a - b - c - d and a separate component x - y
+	if err := os.WriteFile(networkFile, []byte("a\tpp\tb\nb\tpp\tc\nc\tpp\td\nx\tpp\ty\n"), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	arguments.GlobalInteractionStore = types.NewInteractionStore()
+	gim := types.NewGeneIDMap()
+	nwr := readers.NewInitialNetworkReader(slog.Default(), gim).WithFormat(readers.NetworkFormatSIF, "")
+	network := nwr.NewNetworkFromFile(networkFile, false, true)
+	seeds := map[types.GeneID]float64{gim.GetIDFromName("a"): -2}
+	cutoff := 0.01
+
+	weights := map[string]editor.PropagationWeight{
+		"rwr":  editor.NewRandomWalkWeight(network, seeds, 0.5, cutoff),
+		"heat": editor.NewHeatKernelWeight(network, seeds, 1, cutoff),
+	}
+	for name, weight := range weights {
+		score := func(gene string) float64 { return weight.Score(gim.GetIDFromName(types.GeneName(gene))) }
+		// the scores decrease with the distance to the seed
+		if score("a") != 1 {
+			t.Errorf("%s: expected the seed to have score 1, got %f", name, score("a"))
+		}
+		if !(score("a") > score("b") && score("b") > score("c") && score("c") > score("d") && score("d") > cutoff) {
+			t.Errorf("%s: expected decreasing scores along the chain, got %f %f %f %f", name, score("a"), score("b"), score("c"), score("d"))
+		}
+		// genes that can not be reached from the seeds get the cutoff
+		if score("x") != cutoff || score("y") != cutoff {
+			t.Errorf("%s: expected unreachable genes to have score %f, got %f %f", name, cutoff, score("x"), score("y"))
+		}
+	}
+
+	// when always restarting, nothing but the seed is scored
+	weight := editor.NewRandomWalkWeight(network, seeds, 1, 0)
+	for _, gene := range []types.GeneName{"b", "c", "d"} {
+		if score := weight.Score(gim.GetIDFromName(gene)); score != 0 {
+			t.Errorf("expected gene %s to have score 0 when always restarting, got %f", gene, score)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package editor
+
+import (
+	"math/rand"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// swapUnit is an interaction that is swapped as a whole: a directed interaction, or an undirected interaction that is
+// represented by an interaction and its reverse
+type swapUnit struct {
+	from, to           types.GeneID
+	probability        float64
+	reverseProbability float64
+}
+
+// swapClass groups the interactions that can be swapped with each other
+type swapClass struct {
+	interactionType types.InteractionTypeID
+	undirected      bool
+}
+
+// RandomizeNetwork randomizes the network with degree-preserving edge swaps. Two interactions a->b and c->d of the same
+// type are rewired to a->d and c->b, which preserves the in- and out-degree of every gene per interaction type.
+// Undirected interactions are only swapped with undirected interactions, and stay undirected. Swaps that would create
+// self edges or duplicate interactions are rejected. Every interaction is swapped swapsPerInteraction times on average.
+// The interactions keep their probabilities, and new interactions are added to the interaction store of the network.
+func RandomizeNetwork(network *graph.Network, swapsPerInteraction int) (*graph.Network, int) {
+	probabilities := network.Probabilities()
+	// group the interactions per swap class
+	units := make([]swapUnit, 0, len(*probabilities))
+	classes := make([]swapClass, 0, len(*probabilities))
+	unitsPerClass := make(map[swapClass][]int)
+	interactions := make(types.InteractionIDSet)
+	for id, probability := range *probabilities {
+		interactions.Set(id)
+		reverse := id.Reverse()
+		undirected := probabilities.Has(reverse)
+		if undirected && id.From() > id.To() {
+			// the undirected interaction is added from its other end
+			continue
+		}
+		unit := swapUnit{from: id.From(), to: id.To(), probability: probability}
+		if undirected {
+			unit.reverseProbability = probabilities.GetProbability(reverse)
+		}
+		class := swapClass{interactionType: id.Type(), undirected: undirected}
+		unitsPerClass[class] = append(unitsPerClass[class], len(units))
+		units = append(units, unit)
+		classes = append(classes, class)
+	}
+
+	// swap the interactions
+	swaps := 0
+	for range swapsPerInteraction * len(units) {
+		i := rand.Intn(len(units))
+		class := classes[i]
+		candidates := unitsPerClass[class]
+		j := candidates[rand.Intn(len(candidates))]
+		if i == j {
+			continue
+		}
+		a, b := units[i].from, units[i].to
+		c, d := units[j].from, units[j].to
+		if class.undirected && rand.Float64() < 0.5 {
+			// undirected interactions can be rewired in both ways
+			c, d = d, c
+		}
+		if a == d || c == b {
+			continue
+		}
+		ad := types.FromToTypeToID(a, d, class.interactionType)
+		cb := types.FromToTypeToID(c, b, class.interactionType)
+		if interactions.Has(ad) || interactions.Has(cb) ||
+			(class.undirected && (interactions.Has(ad.Reverse()) || interactions.Has(cb.Reverse()))) {
+			continue
+		}
+		for _, unit := range []swapUnit{units[i], units[j]} {
+			id := types.FromToTypeToID(unit.from, unit.to, class.interactionType)
+			interactions.Delete(id)
+			if class.undirected {
+				interactions.Delete(id.Reverse())
+			}
+		}
+		for _, id := range []types.InteractionID{ad, cb} {
+			interactions.Set(id)
+			if class.undirected {
+				interactions.Set(id.Reverse())
+			}
+		}
+		units[i].to = d
+		units[j].from, units[j].to = c, b
+		swaps++
+	}
+
+	// create the randomized network
+	randomized := types.NewProbabilityMap()
+	for i, unit := range units {
+		id := types.FromToTypeToID(unit.from, unit.to, classes[i].interactionType)
+		network.AddInteraction(id)
+		randomized.SetProbability(id, unit.probability)
+		if classes[i].undirected {
+			network.AddInteraction(id.Reverse())
+			randomized.SetProbability(id.Reverse(), unit.reverseProbability)
+		}
+	}
+	return graph.NewNetwork(network.InteractionStore, randomized, network.InteractionTypes(), nil), swaps
+}
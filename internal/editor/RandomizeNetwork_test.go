@@ -0,0 +1,65 @@
+package editor_test
+
+import (
+	"log/slog"
+	"maps"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/graph"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+// typedDegree is the degree of a gene for a single interaction type, in a single direction
+type typedDegree struct {
+	gene            types.GeneID
+	interactionType types.InteractionTypeID
+	outgoing        bool
+	undirected      bool
+}
+
+func typedDegrees(network *graph.Network) map[typedDegree]int {
+	degrees := make(map[typedDegree]int)
+	for id := range *network.Probabilities() {
+		undirected := network.Probabilities().Has(id.Reverse())
+		degrees[typedDegree{id.From(), id.Type(), true, undirected}]++
+		degrees[typedDegree{id.To(), id.Type(), false, undirected}]++
+	}
+	return degrees
+}
+
+func TestRandomizeNetwork(t *testing.T) {
+	arguments.GlobalInteractionStore = types.NewInteractionStore()
+	nwr := readers.NewInitialNetworkReader(slog.Default(), types.NewGeneIDMap())
+	network := nwr.NewNetworkFromFile("testdata/network.txt", false, true)
+
+	randomized, swaps := editor.RandomizeNetwork(network, 2)
+	if swaps == 0 {
+		t.Fatalf("expected interactions to be swapped")
+	}
+	if randomized.InteractionCount() != network.InteractionCount() {
+		t.Errorf("expected %d interactions, got %d", network.InteractionCount(), randomized.InteractionCount())
+	}
+	// the degree of every gene is preserved per interaction type and direction
+	if !maps.Equal(typedDegrees(network), typedDegrees(randomized)) {
+		t.Errorf("expected the randomized network to preserve the degrees")
+	}
+	shared := 0
+	for id := range *randomized.Probabilities() {
+		if id.From() == id.To() {
+			t.Errorf("expected no self edges, got %d", id.From())
+		}
+		if network.Probabilities().Has(id) {
+			shared++
+		}
+		// new interactions can be expanded
+		if !randomized.OutgoingInteractions(id.From()).Has(id) {
+			t.Errorf("expected interaction %d to be in the interaction store", id)
+		}
+	}
+	if shared == network.InteractionCount() {
+		t.Errorf("expected the randomized network to differ from the network")
+	}
+}
@@ -0,0 +1,49 @@
+package editor
+
+import (
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// HubGenes returns the genes that interact with more than maxDegree other genes
+func HubGenes(network *graph.Network, maxDegree int) types.GeneSet {
+	neighbors := make(map[types.GeneID]types.GeneSet)
+	addNeighbor := func(gene, neighbor types.GeneID) {
+		if _, ok := neighbors[gene]; !ok {
+			neighbors[gene] = make(types.GeneSet)
+		}
+		neighbors[gene][neighbor] = struct{}{}
+	}
+	for id := range *network.Probabilities() {
+		from, to := id.FromTo()
+		addNeighbor(from, to)
+		addNeighbor(to, from)
+	}
+	hubs := make(types.GeneSet)
+	for gene, geneNeighbors := range neighbors {
+		if len(geneNeighbors) > maxDegree {
+			hubs[gene] = struct{}{}
+		}
+	}
+	return hubs
+}
+
+// RemoveGenes removes the genes and all their interactions from the network. The interaction store of the network is
+// shared with the networks of other conditions, so the network without the genes gets a filtered copy of the store.
+func RemoveGenes(network *graph.Network, genes types.GeneSet) *graph.Network {
+	if len(genes) == 0 {
+		return network
+	}
+	probabilities := types.NewProbabilityMap()
+	for id, p := range *network.Probabilities() {
+		from, to := id.FromTo()
+		_, removeFrom := genes[from]
+		_, removeTo := genes[to]
+		if !removeFrom && !removeTo {
+			probabilities.SetProbability(id, p)
+		}
+	}
+	// the genes can not be reached by expanding the network either
+	store := network.InteractionStore.WithoutGenes(genes)
+	return graph.NewNetwork(store, probabilities, network.InteractionTypes(), network.Scores())
+}
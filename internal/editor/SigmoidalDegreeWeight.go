@@ -21,33 +21,79 @@ func (s SigmoidalDegreeWeight) Score(gene types.GeneID) float64 {
 	return s.scoreMap[gene]
 }
 
+// NewSigmoidalDegreeWeight scores the genes with a sigmoid of their out-degree
 func NewSigmoidalDegreeWeight(network *graph.Network, cutoff float64) SigmoidalDegreeWeight {
-	scoreMap := make(map[types.GeneID]float64)
+	weight, _ := NewSigmoidalCentralityWeight(network, CentralityOutDegree, cutoff)
+	return weight
+}
+
+// HubCentralityFit is the discrete power law fit of the centralities of the genes, which sets the inflection point of
+// the sigmoid. Pagerank and betweenness are scaled to the range of the out-degrees, and rounded up for the fit, so hubs
+// are penalized on the same scale for every centrality.
+type HubCentralityFit struct {
+	Centralities    map[types.GeneID]float64
+	Collection      []int64
+	PowerLaw        powerlaws.DiscretePowerLaw
+	InflectionPoint float64
+}
+
+// FitHubCentrality fits a discrete power law on the nonzero centralities of the genes, nothing is fitted when all
+// centralities are zero
+func FitHubCentrality(network *graph.Network, centrality string) (HubCentralityFit, error) {
+	centralities, err := Centrality(network, centrality)
+	if err != nil {
+		return HubCentralityFit{}, err
+	}
+	if !isDiscreteCentrality(centrality) {
+		scaleCentralities(network, centralities)
+	}
 	// Power law
-	collection := make([]int64, 0, len(network.Genes()))
-	for gene := range network.Genes() {
-		outdegree := int64(network.OutDegree(gene))
-		if outdegree == 0 {
+	collection := make([]int64, 0, len(centralities))
+	for _, value := range centralities {
+		discrete := int64(math.Ceil(value))
+		if discrete == 0 {
 			continue
 		}
-		collection = append(collection, outdegree)
-	}
-	counts := make(map[int64]int)
-	geneSum := 0
-	interactionSum := int64(0)
-	for _, entry := range collection {
-		if _, ok := counts[entry]; !ok {
-			counts[entry] = 0
-		}
-		counts[entry]++
-		geneSum++
-		interactionSum += entry
+		collection = append(collection, discrete)
+	}
+	if len(collection) == 0 {
+		return HubCentralityFit{Centralities: centralities, Collection: collection}, nil
+	}
+	powerLaw := powerlaws.DiscretePowerLawFit(collection)
+	return HubCentralityFit{
+		Centralities:    centralities,
+		Collection:      collection,
+		PowerLaw:        powerLaw,
+		InflectionPoint: float64(powerLaw.CdfInv(0.1)),
+	}, nil
+}
+
+// NewSigmoidalCentralityWeight scores the genes with a sigmoid of their centrality, whose inflection point is fitted
+// with a discrete power law
+func NewSigmoidalCentralityWeight(network *graph.Network, centrality string, cutoff float64) (SigmoidalDegreeWeight, error) {
+	fit, err := FitHubCentrality(network, centrality)
+	if err != nil {
+		return SigmoidalDegreeWeight{}, err
+	}
+	scoreMap := make(map[types.GeneID]float64)
+	dampingFactor := fit.InflectionPoint / 3
+	for gene, value := range fit.Centralities {
+		scoreMap[gene] = math.Max(cutoff, calculateSigmoidal(value, fit.InflectionPoint, dampingFactor))
+	}
+	return SigmoidalDegreeWeight{scoreMap}, nil
+}
+
+// scaleCentralities scales the centralities such that the highest centrality equals the highest out-degree
+func scaleCentralities(network *graph.Network, centralities map[types.GeneID]float64) {
+	maxCentrality, maxDegree := 0.0, 0
+	for gene, value := range centralities {
+		maxCentrality = math.Max(maxCentrality, value)
+		maxDegree = max(maxDegree, network.OutDegree(gene))
+	}
+	if maxCentrality == 0 {
+		return
 	}
-	inflectionValue := float64(powerlaws.DiscretePowerLawFit(collection).CdfInv(0.1))
-	dampingFactor := inflectionValue / 3
-	for gene := range network.Genes() {
-		degree := float64(network.OutDegree(gene))
-		scoreMap[gene] = math.Max(cutoff, calculateSigmoidal(degree, inflectionValue, dampingFactor))
+	for gene, value := range centralities {
+		centralities[gene] = value / maxCentrality * float64(maxDegree)
 	}
-	return SigmoidalDegreeWeight{scoreMap}
 }
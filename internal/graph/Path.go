@@ -55,8 +55,9 @@ func (p *Path) MultiplyProbability(value float64) {
 }
 
 // Priority returns true if the path has a higher priority than the other path
+// the scores of the start and end genes are included, such that scored paths are ordered by their probability
 func (p *Path) Priority(other *Path) bool {
-	return p.probability > other.probability
+	return p.Probability() > other.Probability()
 }
 
 // Interactions returns the interactions of the path
@@ -82,6 +83,15 @@ func (p *Path) LastInteraction() types.InteractionID {
 
 }
 
+// StartGene returns the gene from which the path starts
+func (p *Path) StartGene() types.GeneID {
+	gene := p.EndGene
+	for i := len(p.interactions) - 1; i >= 0; i-- {
+		gene, _ = p.interactions[i].OtherEndGene(gene)
+	}
+	return gene
+}
+
 // hasGene returns true if the path contains the given gene
 func (p *Path) hasGene(gene types.GeneID) bool {
 	_, has := p.genes[gene]
@@ -30,3 +30,25 @@ func BidirectionalRegulatoryUpDownstreamPathDefinition(path Path) bool {
 func InvertedRegulatoryPathDefinition(path Path) bool {
 	return arguments.GlobalInteractionStore.IsRegulatoryInteraction(path.FirstInteraction())
 }
+
+// NetSign is the product of the signs of the interactions of the path, the net sign is unsigned if any of its
+// interactions is unsigned
+func NetSign(path Path) types.InteractionSign {
+	sign := types.Activation
+	for _, interaction := range path.Interactions() {
+		sign *= arguments.GlobalInteractionStore.Sign(interaction)
+	}
+	return sign
+}
+
+// SignConsistentPathDefinition accepts the paths of the path definition whose net sign agrees with the expected sign of
+// their start gene. Paths with an unsigned net sign, and paths from genes without an expected sign, are rejected.
+func SignConsistentPathDefinition(definition PathDefinition, expectedSign func(types.GeneID) types.InteractionSign) PathDefinition {
+	return func(path Path) bool {
+		if !definition(path) {
+			return false
+		}
+		expected := expectedSign(path.StartGene())
+		return expected != types.Unsigned && NetSign(path) == expected
+	}
+}
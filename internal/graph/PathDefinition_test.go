@@ -0,0 +1,54 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+func TestSignConsistentPathDefinition(t *testing.T) {
+	arguments.GlobalInteractionStore = types.NewInteractionStore()
+	store := arguments.GlobalInteractionStore
+	// the path 1 <- 2 <- 3 <- 4 goes upstream from gene 1, 2 inhibits 1, 3 inhibits 2 and 4 has an unknown effect on 3
+	inhibits21 := types.FromToTypeToID(2, 1, 0)
+	inhibits32 := types.FromToTypeToID(3, 2, 0)
+	unsigned43 := types.FromToTypeToID(4, 3, 0)
+	store.SetSign(inhibits21, types.Inhibition)
+	store.SetSign(inhibits32, types.Inhibition)
+	one := graph.ExtendPath(graph.RootPath(1, 1), inhibits21, 1, types.UpstreamPath)
+	two := graph.ExtendPath(one, inhibits32, 1, types.UpstreamPath)
+	three := graph.ExtendPath(two, unsigned43, 1, types.UpstreamPath)
+
+	for _, tt := range []struct {
+		path     *graph.Path
+		expected types.InteractionSign
+	}{
+		{one, types.Inhibition},
+		{two, types.Activation},
+		{three, types.Unsigned},
+	} {
+		if sign := graph.NetSign(*tt.path); sign != tt.expected {
+			t.Errorf("expected net sign %s for a path of length %d, got %s", tt.expected, tt.path.Length, sign)
+		}
+	}
+
+	// gene 1 is up-regulated
+	definition := graph.SignConsistentPathDefinition(graph.SimplePathDefinition, func(gene types.GeneID) types.InteractionSign {
+		return types.SignOf(map[types.GeneID]float64{1: 2.5}[gene])
+	})
+	if definition(*one) {
+		t.Errorf("expected an inhibiting path to an up-regulated gene to be rejected")
+	}
+	if !definition(*two) {
+		t.Errorf("expected an activating path to an up-regulated gene to be accepted")
+	}
+	if definition(*three) {
+		t.Errorf("expected an unsigned path to be rejected")
+	}
+	// gene 2 has no expression change
+	if definition(*graph.ExtendPath(graph.RootPath(2, 1), inhibits32, 1, types.UpstreamPath)) {
+		t.Errorf("expected a path to a gene without expression change to be rejected")
+	}
+}
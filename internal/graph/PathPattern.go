@@ -0,0 +1,329 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// A path pattern describes the accepted paths as a comma-separated list of clauses, e.g.
+//
+//	first:regulatory, any*, last:type=pd, direction=downstream
+//
+// The step clauses are matched in order against the interactions of a path, starting from the start gene of the search.
+// A step clause is a condition on a single interaction, or on zero or more interactions when it ends with `*`.
+// Conditions combine atoms with `&`, an atom is negated with a leading `!`:
+//
+//	any             any interaction
+//	regulatory      a regulatory interaction
+//	non-regulatory  a non-regulatory interaction
+//	type=pd|pp      an interaction of one of the given types
+//	down            an interaction followed from its source to its target
+//	up              an interaction followed from its target to its source
+//
+// The first step clause may be marked with `first:` and the last one with `last:`. Unmarked ends match any number of
+// interactions, such that `first:regulatory` accepts every path that starts with a regulatory interaction.
+// The `direction=` clause determines how paths are expanded: downstream, upstream, updownstream, downupstream or any.
+// By default, paths may change direction once.
+
+// PatternDirection determines the directions in which a path pattern expands paths
+type PatternDirection string
+
+const (
+	AnyPatternDirection          PatternDirection = "any"
+	DownstreamPatternDirection   PatternDirection = "downstream"
+	UpstreamPatternDirection     PatternDirection = "upstream"
+	UpDownstreamPatternDirection PatternDirection = "updownstream"
+	DownUpstreamPatternDirection PatternDirection = "downupstream"
+)
+
+// stepAtom is a single condition on a step of a path
+type stepAtom struct {
+	negated    bool
+	any        bool
+	regulatory bool
+	types      map[string]struct{}
+	upstream   bool
+	downstream bool
+}
+
+func (atom stepAtom) matches(interaction types.InteractionID, upstream bool) bool {
+	var match bool
+	switch {
+	case atom.any:
+		match = true
+	case atom.types != nil:
+		_, match = atom.types[arguments.GlobalInteractionStore.InteractionType(interaction)]
+	case atom.upstream:
+		match = upstream
+	case atom.downstream:
+		match = !upstream
+	default:
+		match = arguments.GlobalInteractionStore.IsRegulatoryInteraction(interaction) == atom.regulatory
+	}
+	return match != atom.negated
+}
+
+// patternStep matches a single step of a path, or zero or more steps if it is repeated
+type patternStep struct {
+	atoms    []stepAtom
+	repeated bool
+}
+
+func (step patternStep) matches(interaction types.InteractionID, upstream bool) bool {
+	for _, atom := range step.atoms {
+		if !atom.matches(interaction, upstream) {
+			return false
+		}
+	}
+	return true
+}
+
+// PathPattern is a compiled path pattern
+type PathPattern struct {
+	pattern   string
+	steps     []patternStep
+	direction PatternDirection
+}
+
+// CompilePathPattern compiles the path pattern
+func CompilePathPattern(pattern string) (*PathPattern, error) {
+	compiled := &PathPattern{
+		pattern:   pattern,
+		steps:     make([]patternStep, 0),
+		direction: AnyPatternDirection,
+	}
+	clauses := strings.Split(pattern, ",")
+	anchoredFirst, anchoredLast := false, false
+	for i, clause := range clauses {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			return nil, fmt.Errorf("empty clause %d", i+1)
+		}
+		if value, ok := strings.CutPrefix(clause, "direction="); ok {
+			direction := PatternDirection(strings.TrimSpace(value))
+			switch direction {
+			case AnyPatternDirection, DownstreamPatternDirection, UpstreamPatternDirection, UpDownstreamPatternDirection, DownUpstreamPatternDirection:
+				compiled.direction = direction
+			default:
+				return nil, fmt.Errorf("unknown direction %q", value)
+			}
+			continue
+		}
+		if anchoredLast {
+			return nil, fmt.Errorf("step clause %q follows the last step", clause)
+		}
+		if rest, ok := strings.CutPrefix(clause, "first:"); ok {
+			if len(compiled.steps) > 0 {
+				return nil, fmt.Errorf("first step %q is not the first step clause", clause)
+			}
+			anchoredFirst = true
+			clause = rest
+		} else if rest, ok := strings.CutPrefix(clause, "last:"); ok {
+			anchoredLast = true
+			clause = rest
+		}
+		step, err := parsePatternStep(strings.TrimSpace(clause))
+		if err != nil {
+			return nil, fmt.Errorf("clause %q: %w", strings.TrimSpace(clauses[i]), err)
+		}
+		compiled.steps = append(compiled.steps, step)
+	}
+	// unmarked ends match any number of interactions
+	anyStep := patternStep{atoms: []stepAtom{{any: true}}, repeated: true}
+	if !anchoredFirst {
+		compiled.steps = append([]patternStep{anyStep}, compiled.steps...)
+	}
+	if !anchoredLast {
+		compiled.steps = append(compiled.steps, anyStep)
+	}
+	return compiled, nil
+}
+
+func parsePatternStep(clause string) (patternStep, error) {
+	step := patternStep{}
+	clause, step.repeated = strings.CutSuffix(clause, "*")
+	for _, atomString := range strings.Split(clause, "&") {
+		atomString = strings.TrimSpace(atomString)
+		atom := stepAtom{}
+		atomString, atom.negated = strings.CutPrefix(atomString, "!")
+		switch atomString {
+		case "any":
+			atom.any = true
+		case "regulatory":
+			atom.regulatory = true
+		case "non-regulatory":
+			atom.regulatory = false
+		case "up":
+			atom.upstream = true
+		case "down":
+			atom.downstream = true
+		default:
+			typeNames, ok := strings.CutPrefix(atomString, "type=")
+			if !ok {
+				return step, fmt.Errorf("unknown condition %q", atomString)
+			}
+			atom.types = make(map[string]struct{})
+			for _, typeName := range strings.Split(typeNames, "|") {
+				if typeName = strings.TrimSpace(typeName); typeName == "" {
+					return step, errors.New("empty interaction type")
+				}
+				atom.types[typeName] = struct{}{}
+			}
+		}
+		step.atoms = append(step.atoms, atom)
+	}
+	return step, nil
+}
+
+// String returns the pattern as it was compiled
+func (pattern *PathPattern) String() string {
+	return pattern.pattern
+}
+
+// Direction returns the directions in which the pattern expands paths
+func (pattern *PathPattern) Direction() PatternDirection {
+	return pattern.direction
+}
+
+// InteractionTypes returns the interaction types named in the pattern
+func (pattern *PathPattern) InteractionTypes() []string {
+	typeNames := make([]string, 0)
+	for _, step := range pattern.steps {
+		for _, atom := range step.atoms {
+			for typeName := range atom.types {
+				typeNames = append(typeNames, typeName)
+			}
+		}
+	}
+	return typeNames
+}
+
+// closure adds the states that are reachable by skipping repeated steps
+func (pattern *PathPattern) closure(states []bool) []bool {
+	for i, step := range pattern.steps {
+		if states[i] && step.repeated {
+			states[i+1] = true
+		}
+	}
+	return states
+}
+
+// states returns the steps of the pattern that can follow the interactions of the path
+// state i means that the first i steps of the pattern have been matched
+func (pattern *PathPattern) states(path Path) []bool {
+	states := make([]bool, len(pattern.steps)+1)
+	states[0] = true
+	states = pattern.closure(states)
+	gene := path.StartGene()
+	for _, interaction := range path.interactions {
+		upstream := interaction.To() == gene
+		gene, _ = interaction.OtherEndGene(gene)
+		next := make([]bool, len(pattern.steps)+1)
+		for i, step := range pattern.steps {
+			if !states[i] || !step.matches(interaction, upstream) {
+				continue
+			}
+			if step.repeated {
+				next[i] = true
+			} else {
+				next[i+1] = true
+			}
+		}
+		states = pattern.closure(next)
+	}
+	return states
+}
+
+// Matches returns whether the whole path matches the pattern
+func (pattern *PathPattern) Matches(path Path) bool {
+	return pattern.states(path)[len(pattern.steps)]
+}
+
+// viable returns whether the path can still be extended to a path that matches the pattern
+func (pattern *PathPattern) viable(path Path) bool {
+	for _, state := range pattern.states(path) {
+		if state {
+			return true
+		}
+	}
+	return false
+}
+
+// Definition returns the path definition that accepts the paths that match the pattern
+func (pattern *PathPattern) Definition() PathDefinition {
+	return pattern.Matches
+}
+
+// PatternExpander expands paths in the directions of a path pattern, and only keeps paths that can still match it
+type PatternExpander struct {
+	*GenericExpander
+	pattern *PathPattern
+}
+
+func NewPatternExpander(network *Network, pattern *PathPattern) *PatternExpander {
+	return &PatternExpander{
+		NewGenericExpander(network),
+		pattern,
+	}
+}
+
+func (expander *PatternExpander) Expand(path *Path) ([]*Path, error) {
+	incoming := expander.network.Incoming()[path.EndGene]
+	outgoing := expander.network.Outgoing()[path.EndGene]
+	var interactions []types.InteractionIDSet
+	switch expander.pattern.direction {
+	case DownstreamPatternDirection:
+		if !path.isExtended {
+			path.Direction = types.DownstreamPath
+		}
+		interactions = []types.InteractionIDSet{outgoing}
+	case UpstreamPatternDirection:
+		if !path.isExtended {
+			path.Direction = types.UpstreamPath
+		}
+		interactions = []types.InteractionIDSet{incoming}
+	case UpDownstreamPatternDirection:
+		if !path.isExtended {
+			path.Direction = types.UpstreamPath
+			interactions = []types.InteractionIDSet{incoming}
+		} else if path.Direction == types.UpstreamPath {
+			interactions = []types.InteractionIDSet{incoming, outgoing}
+		} else {
+			interactions = []types.InteractionIDSet{outgoing}
+		}
+	case DownUpstreamPatternDirection:
+		if !path.isExtended {
+			path.Direction = types.DownstreamPath
+			interactions = []types.InteractionIDSet{outgoing}
+		} else if path.Direction == types.DownstreamPath {
+			interactions = []types.InteractionIDSet{incoming, outgoing}
+		} else {
+			interactions = []types.InteractionIDSet{incoming}
+		}
+	default:
+		// a path may change direction once
+		switch path.Direction {
+		case types.UpDownstreamPath:
+			interactions = []types.InteractionIDSet{outgoing}
+		case types.DownUpstreamPath:
+			interactions = []types.InteractionIDSet{incoming}
+		default:
+			interactions = []types.InteractionIDSet{incoming, outgoing}
+		}
+	}
+	expanded, err := expander.expandWIP(path, interactions...)
+	if err != nil {
+		return nil, err
+	}
+	viable := expanded[:0]
+	for _, extended := range expanded {
+		if expander.pattern.viable(*extended) {
+			viable = append(viable, extended)
+		}
+	}
+	return viable, nil
+}
@@ -0,0 +1,117 @@
+package graph_test
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+func TestCompilePathPatternErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"",
+		"any,,any",
+		"first:kinase",
+		"type=",
+		"direction=sideways",
+		"any, first:any",
+		"last:any, any",
+	} {
+		if _, err := graph.CompilePathPattern(pattern); err == nil {
+			t.Errorf("expected an error for pattern %q", pattern)
+		}
+	}
+}
+
+func TestPathPattern(t *testing.T) {
+	arguments.GlobalInteractionStore = types.NewInteractionStore()
+	gim := types.NewGeneIDMap()
+	nwr := readers.NewInitialNetworkReader(slog.Default(), gim)
+	network := nwr.NewNetworkFromFile("testdata/pattern-network.csv", false, true)
+
+	// signature writes the genes of the path, with > for downstream and < for upstream steps
+	signature := func(path *graph.Path) string {
+		gene := path.StartGene()
+		var builder strings.Builder
+		builder.WriteString(string(gim.GetNameFromID(gene)))
+		for _, interaction := range path.Interactions() {
+			if interaction.From() == gene {
+				builder.WriteString(">")
+			} else {
+				builder.WriteString("<")
+			}
+			gene, _ = interaction.OtherEndGene(gene)
+			builder.WriteString(string(gim.GetNameFromID(gene)))
+		}
+		return builder.String()
+	}
+
+	tests := []struct {
+		pattern  string
+		expanded []string
+		matched  []string
+	}{
+		{
+			pattern:  "first:regulatory, any*, last:type=pd, direction=downstream",
+			expanded: []string{"A>B", "A>B>C", "A>B>C>D"},
+			// the first and the last step are different steps
+			matched: []string{"A>B>C>D"},
+		},
+		{
+			pattern:  "first:type=pp",
+			expanded: []string{},
+			matched:  []string{},
+		},
+		{
+			pattern:  "down, up",
+			expanded: []string{"A>B", "A>B>C", "A>B>C<E", "A>B>C>D"},
+			matched:  []string{"A>B>C<E"},
+		},
+		{
+			pattern:  "first:regulatory, non-regulatory&!up*, last:regulatory",
+			expanded: []string{"A>B", "A>B>C", "A>B>C>D"},
+			matched:  []string{"A>B>C>D"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			pattern, err := graph.CompilePathPattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("failed to compile pattern: %v", err)
+			}
+			expander := graph.NewPatternExpander(network, pattern)
+			definition := pattern.Definition()
+			expanded := make([]string, 0)
+			matched := make([]string, 0)
+			stack := []*graph.Path{graph.RootPath(gim.GetIDFromName("A"), 1)}
+			for len(stack) > 0 {
+				current := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				paths, err := expander.Expand(current)
+				if err != nil {
+					t.Fatalf("failed to expand %s: %v", signature(current), err)
+				}
+				for _, path := range paths {
+					expanded = append(expanded, signature(path))
+					if definition(*path) {
+						matched = append(matched, signature(path))
+					}
+				}
+				stack = append(stack, paths...)
+			}
+			slices.Sort(expanded)
+			slices.Sort(matched)
+			if !slices.Equal(expanded, tt.expanded) {
+				t.Errorf("expected expanded paths %v, got %v", tt.expanded, expanded)
+			}
+			if !slices.Equal(matched, tt.matched) {
+				t.Errorf("expected matched paths %v, got %v", tt.matched, matched)
+			}
+		})
+	}
+}
@@ -79,3 +79,17 @@ func TestExtendPath(t *testing.T) {
 		t.Errorf("Expected interactions %+v to contain the new interaction %d", interactions, interactionID)
 	}
 }
+
+func TestPathPriority(t *testing.T) {
+	// the path with the higher probability but the lower start score has the lower priority
+	scored := graph.RootPath(types.GeneID(1), 0.5)
+	unscored := graph.RootPath(types.GeneID(2), 1)
+	interaction := types.FromToToID(types.GeneID(2), types.GeneID(3))
+	extended := graph.ExtendPath(unscored, interaction, 0.8, types.DownstreamPath)
+	if scored.Priority(extended) {
+		t.Errorf("Expected a path with probability %f to have a lower priority than a path with probability %f", scored.Probability(), extended.Probability())
+	}
+	if !extended.Priority(scored) {
+		t.Errorf("Expected a path with probability %f to have a higher priority than a path with probability %f", extended.Probability(), scored.Probability())
+	}
+}
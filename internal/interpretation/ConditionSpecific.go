@@ -42,12 +42,14 @@ func EdgesInCondition(
 	goiInCondition := make(map[string]map[types.Condition]map[types.GeneID]struct{})
 	goiInCondition["differential expression"] = make(map[types.Condition]map[types.GeneID]struct{})
 	goiInCondition["mutation"] = make(map[types.Condition]map[types.GeneID]struct{})
+	goiInCondition["cna"] = make(map[types.Condition]map[types.GeneID]struct{})
 	// map conditions to their index
 	conditionIndexMap := make(map[types.Condition]int)
 	for i, condition := range orderedConditions {
 		conditionIndexMap[condition] = i
 		goiInCondition["differential expression"][condition] = make(map[types.GeneID]struct{})
 		goiInCondition["mutation"][condition] = make(map[types.GeneID]struct{})
+		goiInCondition["cna"][condition] = make(map[types.GeneID]struct{})
 	}
 
 	for _, pathType := range pathTypes {
@@ -102,6 +104,9 @@ func EdgesInCondition(
 			break
 		case "mutation":
 			addGenesOfInterest(pathsForType, 0, "mutation", goiInCondition)
+			break
+		case "cna":
+			addGenesOfInterest(pathsForType, 0, "cna", goiInCondition)
 		}
 		logger.Info("Condition-specific paths",
 			slog.String("pathType", pathType),
@@ -116,7 +121,7 @@ func EdgesInCondition(
 func addGenesOfInterest(
 	pathsPerPathType map[types.Condition]map[types.GeneID][2]float64,
 	scoreIndex int, // 0 for start gene, 1 for end gene
-	goiType string, // "differential expression" or "mutation"
+	goiType string, // "differential expression", "mutation" or "cna"
 	goiInCondition map[string]map[types.Condition]map[types.GeneID]struct{},
 ) {
 	if _, ok := goiInCondition[goiType]; !ok {
@@ -135,9 +140,11 @@ func addGenesOfInterest(
 }
 
 // WriteConditionSpecificRanking writes the condition-specific ranking to a file
+// if empirical p-values are given, they are written after the score of each gene
 func (interpreter Interpreter) WriteConditionSpecificRanking(
 	fileWriter *fileio.FileWriter,
 	ranking map[string]map[types.Condition]map[types.GeneID]float64,
+	pValues map[string]map[types.Condition]map[types.GeneID]float64,
 	conditions types.Conditions,
 	directory string,
 	geneMapping types.GeneTranslationMap,
@@ -147,6 +154,7 @@ func (interpreter Interpreter) WriteConditionSpecificRanking(
 			fileWriter,
 			identifier,
 			ranking[identifier],
+			pValues[identifier],
 			conditions,
 			directory,
 			geneMapping,
@@ -163,6 +171,7 @@ func (interpreter Interpreter) writeConditionSpecificRanking(
 	fileWriter *fileio.FileWriter,
 	identifier string,
 	ranking map[types.Condition]map[types.GeneID]float64,
+	pValues map[types.Condition]map[types.GeneID]float64,
 	conditions types.Conditions,
 	directory string,
 	geneMapping types.GeneTranslationMap,
@@ -174,7 +183,11 @@ func (interpreter Interpreter) writeConditionSpecificRanking(
 		line = append(line, condition.String())
 		for _, gene := range sorted {
 			name := interpreter.GetMappedName(gene, geneMapping)
-			line = append(line, fmt.Sprintf("%s %f", name, ranking[condition][gene]))
+			if pValues == nil {
+				line = append(line, fmt.Sprintf("%s %f", name, ranking[condition][gene]))
+				continue
+			}
+			line = append(line, fmt.Sprintf("%s %f %f", name, ranking[condition][gene], pValues[condition][gene]))
 		}
 		linesToWrite = append(linesToWrite, strings.Join(line, "\t"))
 	}
@@ -185,7 +198,7 @@ func (interpreter Interpreter) writeConditionSpecificRanking(
 }
 
 // ConditionSpecificRanking ranks genes based on their presence in the final subnetwork per condition
-// Where top-level keys are "DE" and "mut" (the roles). Mapping logic:
+// Where top-level keys are "DE", "mutation" and "cna" (the roles). Mapping logic:
 // Create a combined ranking that sums appropriate scores across all relevant path types for each role.
 func ConditionSpecificRanking(
 	orderedConditions types.Conditions,
@@ -195,6 +208,7 @@ func ConditionSpecificRanking(
 	for _, condition := range orderedConditions {
 		deScores := make(map[types.GeneID]float64)
 		mutScores := make(map[types.GeneID]float64)
+		cnaScores := make(map[types.GeneID]float64)
 
 		// Handle eqtl
 		if eqtlPaths, ok := pathsPerPathType["eqtl"]; ok {
@@ -227,6 +241,15 @@ func ConditionSpecificRanking(
 				}
 			}
 		}
+
+		// Handle copy-number alterations
+		if cnaPaths, ok := pathsPerPathType["cna"]; ok {
+			for gene, scores := range cnaPaths[condition] {
+				if scores[0] != 0 {
+					cnaScores[gene] += scores[0]
+				}
+			}
+		}
 		if len(deScores) > 0 {
 			if _, exists := ranking["DE"]; !exists {
 				ranking["DE"] = make(map[types.Condition]map[types.GeneID]float64)
@@ -239,6 +262,12 @@ func ConditionSpecificRanking(
 			}
 			ranking["mutation"][condition] = mutScores
 		}
+		if len(cnaScores) > 0 {
+			if _, exists := ranking["cna"]; !exists {
+				ranking["cna"] = make(map[types.Condition]map[types.GeneID]float64)
+			}
+			ranking["cna"][condition] = cnaScores
+		}
 	}
 
 	return ranking
@@ -258,6 +258,7 @@ func TestConditionSpecificRanking(t *testing.T) {
 	interpreter.WriteConditionSpecificRanking(
 		interpreter.FileWriter,
 		conditionSpecificGeneRanking,
+		nil,
 		conditions,
 		dir,
 		make(types.GeneTranslationMap),
@@ -0,0 +1,54 @@
+package interpretation
+
+import (
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// EmpiricalPValues computes for each ranked gene how surprising its score is, given the rankings of permuted data.
+// The observed ranking counts as one of the permutations, i.e. p = (1 + #{permutations scoring at least as high}) / (1 + #permutations).
+// Genes that do not appear in the ranking of a permutation have a score of 0 in that permutation.
+func EmpiricalPValues(
+	observed map[string]map[types.Condition]map[types.GeneID]float64,
+	null []map[string]map[types.Condition]map[types.GeneID]float64,
+) map[string]map[types.Condition]map[types.GeneID]float64 {
+	pValues := make(map[string]map[types.Condition]map[types.GeneID]float64, len(observed))
+	for identifier, conditions := range observed {
+		pValues[identifier] = make(map[types.Condition]map[types.GeneID]float64, len(conditions))
+		for condition, genes := range conditions {
+			pValues[identifier][condition] = make(map[types.GeneID]float64, len(genes))
+			for gene, score := range genes {
+				atLeastAsHigh := 0
+				for _, permutation := range null {
+					if permutation[identifier][condition][gene] >= score {
+						atLeastAsHigh++
+					}
+				}
+				pValues[identifier][condition][gene] = float64(1+atLeastAsHigh) / float64(1+len(null))
+			}
+		}
+	}
+	return pValues
+}
+
+// NullFrequencies counts in how many permutations each gene appears in the ranking, i.e. in the final network
+func NullFrequencies(
+	null []map[string]map[types.Condition]map[types.GeneID]float64,
+) map[string]map[types.Condition]map[types.GeneID]int {
+	frequencies := make(map[string]map[types.Condition]map[types.GeneID]int)
+	for _, permutation := range null {
+		for identifier, conditions := range permutation {
+			if _, ok := frequencies[identifier]; !ok {
+				frequencies[identifier] = make(map[types.Condition]map[types.GeneID]int)
+			}
+			for condition, genes := range conditions {
+				if _, ok := frequencies[identifier][condition]; !ok {
+					frequencies[identifier][condition] = make(map[types.GeneID]int)
+				}
+				for gene := range genes {
+					frequencies[identifier][condition][gene]++
+				}
+			}
+		}
+	}
+	return frequencies
+}
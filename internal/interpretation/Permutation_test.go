@@ -0,0 +1,37 @@
+package interpretation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func TestEmpiricalPValues(t *testing.T) {
+	observed := map[string]map[types.Condition]map[types.GeneID]float64{
+		"mutation": {"s1": {1: 2.0, 2: 0.5}},
+	}
+	null := []map[string]map[types.Condition]map[types.GeneID]float64{
+		{"mutation": {"s1": {1: 1.0, 2: 0.5}}},
+		{"mutation": {"s1": {2: 1.0}}},
+		{"mutation": {"s1": {1: 3.0, 3: 1.0}}},
+		{},
+	}
+	pValues := EmpiricalPValues(observed, null)
+	expected := map[types.GeneID]float64{
+		1: 2.0 / 5.0, // only the third permutation scores at least 2
+		2: 3.0 / 5.0, // the first and the second permutation score at least 0.5
+	}
+	for gene, pValue := range expected {
+		if math.Abs(pValues["mutation"]["s1"][gene]-pValue) > 1e-12 {
+			t.Errorf("expected p-value %f for gene %d, got %f", pValue, gene, pValues["mutation"]["s1"][gene])
+		}
+	}
+
+	frequencies := NullFrequencies(null)
+	for gene, frequency := range map[types.GeneID]int{1: 2, 2: 2, 3: 1} {
+		if frequencies["mutation"]["s1"][gene] != frequency {
+			t.Errorf("expected gene %d in %d permutations, got %d", gene, frequency, frequencies["mutation"]["s1"][gene])
+		}
+	}
+}
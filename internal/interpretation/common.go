@@ -3,6 +3,7 @@ package interpretation
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
 	"github.com/MarchalLab/gonetic/internal/common/fileio"
@@ -39,6 +40,60 @@ func (interpreter Interpreter) WriteWeightedSubnetwork(
 	return interpreter.WriteLinesToNewFile(weightedNetworkfileName, interactionLines)
 }
 
+// WriteStabilitySubnetwork writes the weighted subnetwork, annotated with the fraction of bootstrap replicates in which
+// each interaction is selected
+func (interpreter Interpreter) WriteStabilitySubnetwork(
+	stabilityNetworkFileName string,
+	weightedInteractions map[types.InteractionID][]float64,
+	stability map[types.InteractionID]float64,
+	geneMapping types.GeneTranslationMap,
+) error {
+	interactionLines := make([]string, 0, len(weightedInteractions)+1)
+	interactionLines = append(interactionLines, "#from\tto\tinteractionType\trank\tstability")
+	for interaction, scores := range weightedInteractions {
+		score := 0.0
+		for _, s := range scores {
+			score += s
+		}
+		from := interpreter.GetMappedName(interaction.From(), geneMapping)
+		to := interpreter.GetMappedName(interaction.To(), geneMapping)
+		interactionLines = append(interactionLines, fmt.Sprintf(
+			"%s\t%s\t%d\t%f\t%f",
+			from,
+			to,
+			interaction.Type(),
+			score,
+			stability[interaction],
+		))
+	}
+	return interpreter.WriteLinesToNewFile(stabilityNetworkFileName, interactionLines)
+}
+
+// WriteUnreachableGenes writes the genes of interest that were removed from the network, which can not be part of any
+// resulting network, together with the reason of their removal
+func (interpreter Interpreter) WriteUnreachableGenes(
+	unreachableFileName string,
+	removed map[types.GeneName]string,
+	genesOfInterest map[string]GenesOfInterest,
+	geneMapping types.GeneTranslationMap,
+) error {
+	lines := make([]string, 0)
+	for identifier, goi := range genesOfInterest {
+		for gene, conditions := range goi.Genes {
+			reason, ok := removed[interpreter.GetNameFromID(gene)]
+			if !ok {
+				continue
+			}
+			name := interpreter.GetMappedName(gene, geneMapping)
+			for condition := range conditions {
+				lines = append(lines, fmt.Sprintf("%s\t%s\t%s\t%s", identifier, condition, name, reason))
+			}
+		}
+	}
+	slices.Sort(lines)
+	return interpreter.WriteLinesToNewFile(unreachableFileName, []string{"#data\tcondition\tgene\treason"}, lines)
+}
+
 func checkOfInterest(
 	genesOfInterest GenesOfInterest,
 	geneMap map[types.GeneID]int,
@@ -1,6 +1,7 @@
 package normalform
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
@@ -77,6 +78,39 @@ func (compiler DDNNFCompiler) CompileDDNNFs(nfDir string) error {
 	return nil
 }
 
+// ReuseDDNNFs copies the d-DNNFs that were compiled in reuseDir to nfDir, for the compiled CNFs that are identical in
+// both directories. CompileDDNNFs skips the CNFs that already have a d-DNNF. It returns the number of reused d-DNNFs.
+func ReuseDDNNFs(nfDir, reuseDir string) (int, error) {
+	entries, err := os.ReadDir(nfDir)
+	if err != nil {
+		return 0, err
+	}
+	reused := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		compiledCNF, err := os.ReadFile(filepath.Join(nfDir, entry.Name(), "compiled.cnf"))
+		if err != nil {
+			return reused, err
+		}
+		reusableCNF, err := os.ReadFile(filepath.Join(reuseDir, entry.Name(), "compiled.cnf"))
+		if err != nil || !bytes.Equal(compiledCNF, reusableCNF) {
+			// the CNF was not compiled before, or it changed
+			continue
+		}
+		ddnnf, err := os.ReadFile(filepath.Join(reuseDir, entry.Name(), "compiled.cnf.nnf"))
+		if err != nil {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(nfDir, entry.Name(), "compiled.cnf.nnf"), ddnnf, 0644); err != nil {
+			return reused, err
+		}
+		reused++
+	}
+	return reused, nil
+}
+
 // LoadDDNNFs loads d-DNNFs from disk
 func (compiler DDNNFCompiler) LoadDDNNFs(nfDir string) ([]*NNF, error) {
 	compiler.Info("Reading d-DNNFs.")
@@ -0,0 +1,46 @@
+package normalform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+)
+
+func TestReuseDDNNFs(t *testing.T) {
+	reuseDir := filepath.Join("testresult", "reuse", "full")
+	nfDir := filepath.Join("testresult", "reuse", "leave-one-out")
+	write := func(dir, header, fileName, content string) {
+		fileio.CreateDirKeepContent(filepath.Join(dir, header))
+		if err := os.WriteFile(filepath.Join(dir, header, fileName), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", fileName, err)
+		}
+	}
+	fileio.CreateEmptyDir(reuseDir)
+	fileio.CreateEmptyDir(nfDir)
+	// an unchanged CNF, a changed CNF and a CNF that was not compiled before
+	for _, header := range []string{"s1_1", "s1_2"} {
+		write(reuseDir, header, "compiled.cnf", "p cnf 2 1\n1 2 0\n")
+		write(reuseDir, header, "compiled.cnf.nnf", "nnf "+header)
+	}
+	write(nfDir, "s1_1", "compiled.cnf", "p cnf 2 1\n1 2 0\n")
+	write(nfDir, "s1_2", "compiled.cnf", "p cnf 2 1\n1 -2 0\n")
+	write(nfDir, "s2_1", "compiled.cnf", "p cnf 2 1\n1 2 0\n")
+
+	reused, err := ReuseDDNNFs(nfDir, reuseDir)
+	if err != nil {
+		t.Fatalf("failed to reuse d-DNNFs: %v", err)
+	}
+	if reused != 1 {
+		t.Errorf("expected 1 reused d-DNNF, got %d", reused)
+	}
+	if content, err := os.ReadFile(filepath.Join(nfDir, "s1_1", "compiled.cnf.nnf")); err != nil || string(content) != "nnf s1_1" {
+		t.Errorf("expected the d-DNNF of the unchanged CNF to be reused, got %q (%v)", content, err)
+	}
+	for _, header := range []string{"s1_2", "s2_1"} {
+		if _, err := os.Stat(filepath.Join(nfDir, header, "compiled.cnf.nnf")); err == nil {
+			t.Errorf("expected the d-DNNF of %s to be compiled again", header)
+		}
+	}
+}
@@ -55,6 +55,7 @@ func (runner MORunner) Run(pathRepositories *PathRepositories, dDNNFList [][]*no
 	opt.parallelScoreCalc()
 	networksPerSize := make(map[int]int)
 	writtenSubnetworks := make([]subnetwork, 0, len(subnetworks))
+	writtenFiles := make([]string, 0, len(subnetworks))
 	for _, network := range subnetworks {
 		// final duplicate check
 		if isDuplicate(network, writtenSubnetworks) {
@@ -63,8 +64,11 @@ func (runner MORunner) Run(pathRepositories *PathRepositories, dDNNFList [][]*no
 		writtenSubnetworks = append(writtenSubnetworks, network)
 		// write the subnetwork to a file
 		networksPerSize[network.subnetworkSize()]++
-		runner.writeSubNetwork(network, network.Scores(), networksPerSize[network.subnetworkSize()])
+		file := runner.writeSubNetwork(network, network.Scores(), networksPerSize[network.subnetworkSize()])
+		writtenFiles = append(writtenFiles, file)
 	}
+	// write the complete front to a single structured file
+	runner.writeFront(opt.ObjectiveTypes, writtenSubnetworks, writtenFiles)
 	//save time
 	endTime := time.Since(start)
 	err := runner.AppendLinesToFile(
@@ -77,10 +81,13 @@ func (runner MORunner) Run(pathRepositories *PathRepositories, dDNNFList [][]*no
 	runner.Info("Finished MO optimization", "seconds", endTime.Seconds())
 }
 
-func (runner MORunner) writeSubNetwork(network subnetwork, scores []float64, idx int) {
-	outDir := filepath.Join(runner.directory, fmt.Sprintf("size_%d", network.subnetworkSize()))
+// writeSubNetwork writes the subnetwork to a file and returns the file name relative to the optimization directory
+func (runner MORunner) writeSubNetwork(network subnetwork, scores []float64, idx int) string {
+	sizeDir := fmt.Sprintf("size_%d", network.subnetworkSize())
+	outDir := filepath.Join(runner.directory, sizeDir)
 	fileio.CreateDirKeepContent(outDir)
-	outFilePath := filepath.Join(outDir, fmt.Sprintf("result-%d.network", idx))
+	fileName := fmt.Sprintf("result-%d.network", idx)
+	outFilePath := filepath.Join(outDir, fileName)
 	// Write the optimal subnetwork to a file
 	interactionTypeLines := runner.InteractionStore.InteractionTypeStringList()
 	interactionLines := make([]string, 0, network.interactionCount())
@@ -92,4 +99,5 @@ func (runner MORunner) writeSubNetwork(network subnetwork, scores []float64, idx
 	if err != nil {
 		runner.Error("Could not write subnetwork to file", "err", err)
 	}
+	return filepath.Join(sizeDir, fileName)
 }
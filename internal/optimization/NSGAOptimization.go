@@ -32,6 +32,8 @@ type NSGAOptimization struct {
 	populationN        int
 	numGenerations     int
 	mutationChance     float64
+	adaptiveRates      *adaptiveRates
+	crossoverNames     []string
 	availableCores     int
 	duplicateDetection bool
 	initialPopulations int
@@ -41,6 +43,8 @@ type NSGAOptimization struct {
 	gensPerWindow  int
 	hyperVolumeArr []float64
 	startTime      time.Time
+	crossoverStats *operatorStatistics
+	variationStats *operatorStatistics
 	// track best front
 	bestHypervolume      float64
 	bestPopulation       int
@@ -100,10 +104,14 @@ func newNSGAOptimization(
 		populationN:        popSize - popSize%2,
 		numGenerations:     numGenerations,
 		mutationChance:     mutChance,
+		crossoverNames:     args.Crossover,
+		adaptiveRates:      adaptiveRatesFor(args.AdaptiveOperatorRates, mutChance),
 		availableCores:     availableCores,
 		duplicateDetection: true,
 		initialPopulations: 10,
 		bestHypervolume:    0,
+		crossoverStats:     newCrossoverStatistics(),
+		variationStats:     newVariationStatistics(),
 		NetworkSizeOptimizer: NewNetworkSizeOptimizer(
 			args.Logger,
 			objectiveTypes,
@@ -324,67 +332,50 @@ func (opt *NSGAOptimization) generateChild() subnetwork {
 	// select parents and get their paths
 	parentPathIDs := opt.selectParentPaths()
 
-	// track duplicates
-	addedPaths := make(map[PathID]struct{})
-
-	// initialize child
-	child := newFastSubnetwork(opt)
-
 	// generate random target size
 	targetSize := opt.NetworkSizeOptimizer.SampleNetworkSize()
 
 	// perform crossover
-	childDone := false
-	currentParent := 0
-	for !childDone {
-		if len(parentPathIDs[currentParent]) == 0 {
-			// only one parent with paths left, switch back
-			currentParent = 1 - currentParent
-		}
-		if len(parentPathIDs[currentParent]) == 0 {
-			// both parents have no paths left, break
-			break
-		}
-
-		// get random path from current parent and add to child
-		nPaths := len(parentPathIDs[currentParent])
-		idx := rand.Intn(nPaths)
-		pathID := parentPathIDs[currentParent][idx]
-
-		// delete this element from paths slice, by copying last element and then removing last element from slice
-		parentPathIDs[currentParent][idx] = parentPathIDs[currentParent][nPaths-1]
-		parentPathIDs[currentParent] = parentPathIDs[currentParent][:nPaths-1]
+	child := opt.crossover(parentPathIDs, targetSize)
 
-		// check for duplicate paths
-		if _, ok := addedPaths[pathID]; ok {
-			continue
-		}
-		addedPaths[pathID] = struct{}{}
-
-		// add selected path to child
-		path := opt.pathRepositories.pathInteractionSetFromId(pathID)
-		child.addSelectedPath(pathID, path)
-		for interactionID := range path {
-			child.addInteraction(interactionID)
-		}
+	// mutate with random chance
+	if rand.Float64() < opt.currentMutationChance() {
+		opt.mutate(child)
+	}
+	return child
+}
 
-		// switch parents
-		currentParent = 1 - currentParent
+// adaptiveRatesFor returns adaptive operator rates if requested, nil otherwise
+func adaptiveRatesFor(adaptive bool, mutationChance float64) *adaptiveRates {
+	if !adaptive {
+		return nil
+	}
+	return newAdaptiveRates(mutationChance)
+}
 
-		// check if done, i.e. target size reached
-		if child.subnetworkSize() >= targetSize {
-			childDone = true
-		}
+// currentMutationChance returns the mutation chance, which changes over the generations if the operator rates are adaptive
+func (opt *NSGAOptimization) currentMutationChance() float64 {
+	if opt.adaptiveRates == nil {
+		return opt.mutationChance
 	}
+	return opt.adaptiveRates.mutationChance()
+}
 
-	// mutate with random chance
-	if rand.Float64() < opt.mutationChance {
-		// ONLY EXPANSION: (almost) all networks achieved by reduction can be achieved by crossover with lower target size
-		if child.subnetworkSize() < opt.NetworkSizeOptimizer.CurrentMax {
-			child.expansion()
+// mutate applies an expansion or reduction to the child
+// Without adaptive operator rates, only expansions are used:
+// (almost) all networks achieved by reduction can be achieved by crossover with lower target size
+func (opt *NSGAOptimization) mutate(child subnetwork) {
+	if opt.adaptiveRates != nil && rand.Float64() >= opt.adaptiveRates.expansionShare() {
+		if child.subnetworkSize() > opt.NetworkSizeOptimizer.CurrentMin {
+			child.reduction()
+			child.setVariationOperator(reductionVariation)
 		}
+		return
+	}
+	if child.subnetworkSize() < opt.NetworkSizeOptimizer.CurrentMax {
+		child.expansion()
+		child.setVariationOperator(expansionVariation)
 	}
-	return child
 }
 
 // detectDuplicates checks whether child is already present in the population, by comparing path lists
@@ -482,6 +473,8 @@ func (opt *NSGAOptimization) Optimize() []subnetwork {
 		"total time", fmt.Sprintf("%.3f s", endTime.Seconds()),
 		"time per generation", fmt.Sprintf("%.3f s", endTime.Seconds()/float64(t)),
 	)
+	opt.Info("crossover statistics (survived/produced)", crossoverLogAttributes(opt.crossoverStats.produced, opt.crossoverStats.survived)...)
+	opt.Info("variation statistics (survived/produced)", crossoverLogAttributes(opt.variationStats.produced, opt.variationStats.survived)...)
 	// load best population
 	opt.populationFromFile("best_", opt.bestPopulation)
 	opt.Info("best population",
@@ -639,11 +632,36 @@ func (opt *NSGAOptimization) computeGeneration(t, targetSize int) {
 	opt.Pt = selected
 	// calculate hyperVolume and store for early termination calculation
 	if t >= 0 {
+		opt.updateOperatorStatistics(t)
 		hyperVolume := opt.calculateHyperVolume(t, fronts[1])
 		opt.hyperVolumeArr = append(opt.hyperVolumeArr, hyperVolume)
 	}
 }
 
+// updateOperatorStatistics logs, per operator, how many children of the offspring Qt made it into Pt
+// and adapts the operator rates accordingly if requested
+func (opt *NSGAOptimization) updateOperatorStatistics(t int) {
+	if opt.crossoverStats == nil {
+		opt.crossoverStats = newCrossoverStatistics()
+	}
+	if opt.variationStats == nil {
+		opt.variationStats = newVariationStatistics()
+	}
+	produced, survived := opt.crossoverStats.countOffspring(opt.Qt, opt.Pt)
+	attributes := append([]any{"generation", t}, crossoverLogAttributes(produced, survived)...)
+	opt.Info("crossover statistics (survived/produced)", attributes...)
+	produced, survived = opt.variationStats.countOffspring(opt.Qt, opt.Pt)
+	attributes = append([]any{"generation", t}, crossoverLogAttributes(produced, survived)...)
+	if opt.adaptiveRates != nil {
+		opt.adaptiveRates.update(produced, survived)
+		attributes = append(attributes,
+			"next mutation chance", fmt.Sprintf("%.3f", opt.adaptiveRates.mutationChance()),
+			"next expansion share", fmt.Sprintf("%.3f", opt.adaptiveRates.expansionShare()),
+		)
+	}
+	opt.Info("variation statistics (survived/produced)", attributes...)
+}
+
 // parallelScoreCalc calculates scores for subnetworks in parallel
 // It checks if scores for each subnetwork in (opt.Pt + opt.Qt) are already computed
 // And computes them if not
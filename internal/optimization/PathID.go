@@ -21,3 +21,8 @@ func (id PathID) SampleIndex() int {
 func (id PathID) PathIndex() int {
 	return int(id & 0xFFFFFFFF) // 32 bits
 }
+
+// ConditionKey returns the path type and sample index of the path, identifying its condition
+func (id PathID) ConditionKey() PathID {
+	return id >> 32
+}
@@ -0,0 +1,91 @@
+package optimization
+
+import "github.com/MarchalLab/gonetic/internal/common/compare"
+
+// names of the variation operators, i.e. the last operator that shaped a child
+const (
+	crossoverVariation = "crossover"
+	expansionVariation = "expansion"
+	reductionVariation = "reduction"
+)
+
+// newVariationStatistics tracks the success of crossover-only, expansion-mutated and reduction-mutated children
+func newVariationStatistics() *operatorStatistics {
+	stats := newCrossoverStatistics()
+	stats.operatorOf = subnetwork.VariationOperator
+	return stats
+}
+
+// adaptiveRates adapts the probabilities of the variation operators by probability matching:
+// the quality of each operator tracks the rate at which its children enter the next population,
+// and each operator is applied with a probability proportional to its quality.
+// Every operator keeps a minimal probability, so that it can recover when it starts to pay off.
+type adaptiveRates struct {
+	quality        map[string]float64
+	learningRate   float64
+	minProbability float64
+}
+
+var variationOperators = []string{crossoverVariation, expansionVariation, reductionVariation}
+
+// newAdaptiveRates initializes the rates such that children are mutated with the given chance,
+// where expansion and reduction are equally likely
+func newAdaptiveRates(mutationChance float64) *adaptiveRates {
+	rates := &adaptiveRates{
+		quality:        make(map[string]float64),
+		learningRate:   0.3,
+		minProbability: 0.05,
+	}
+	mutationChance = compare.Between(0, mutationChance, 1)
+	initial := map[string]float64{
+		crossoverVariation: 1 - mutationChance,
+		expansionVariation: mutationChance / 2,
+		reductionVariation: mutationChance / 2,
+	}
+	// invert the probability matching, such that the initial probabilities match the requested ones
+	for operator, probability := range initial {
+		rates.quality[operator] = max(0, (probability-rates.minProbability)/rates.freeProbability())
+	}
+	return rates
+}
+
+// freeProbability is the probability mass that is distributed proportional to the qualities
+func (rates *adaptiveRates) freeProbability() float64 {
+	return 1 - float64(len(variationOperators))*rates.minProbability
+}
+
+// update moves the quality of each operator towards its success rate in the last generation
+// operators without children in the last generation keep their quality
+func (rates *adaptiveRates) update(produced, survived map[string]int) {
+	for _, operator := range variationOperators {
+		if produced[operator] == 0 {
+			continue
+		}
+		reward := float64(survived[operator]) / float64(produced[operator])
+		rates.quality[operator] += rates.learningRate * (reward - rates.quality[operator])
+	}
+}
+
+// probability returns the probability of applying the operator
+func (rates *adaptiveRates) probability(operator string) float64 {
+	total := 0.0
+	for _, op := range variationOperators {
+		total += rates.quality[op]
+	}
+	share := 1 / float64(len(variationOperators))
+	if total > 0 {
+		share = rates.quality[operator] / total
+	}
+	return rates.minProbability + rates.freeProbability()*share
+}
+
+// mutationChance returns the probability that a child is mutated after crossover
+func (rates *adaptiveRates) mutationChance() float64 {
+	return 1 - rates.probability(crossoverVariation)
+}
+
+// expansionShare returns the probability that a mutation is an expansion rather than a reduction
+func (rates *adaptiveRates) expansionShare() float64 {
+	expansion := rates.probability(expansionVariation)
+	return expansion / (expansion + rates.probability(reductionVariation))
+}
@@ -0,0 +1,69 @@
+package optimization
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAdaptiveRates_Initial(t *testing.T) {
+	rates := newAdaptiveRates(0.5)
+	if math.Abs(rates.mutationChance()-0.5) > 1e-9 {
+		t.Errorf("expected initial mutation chance 0.5, got %f", rates.mutationChance())
+	}
+	if math.Abs(rates.expansionShare()-0.5) > 1e-9 {
+		t.Errorf("expected initial expansion share 0.5, got %f", rates.expansionShare())
+	}
+}
+
+func TestAdaptiveRates_Update(t *testing.T) {
+	rates := newAdaptiveRates(0.5)
+	produced := map[string]int{crossoverVariation: 10, expansionVariation: 10, reductionVariation: 10}
+	survived := map[string]int{crossoverVariation: 1, expansionVariation: 1, reductionVariation: 8}
+	for i := 0; i < 50; i++ {
+		rates.update(produced, survived)
+	}
+	if rates.expansionShare() >= 0.5 {
+		t.Errorf("expected reduction to be favoured, got expansion share %f", rates.expansionShare())
+	}
+	if rates.mutationChance() <= 0.5 {
+		t.Errorf("expected mutation to be favoured, got mutation chance %f", rates.mutationChance())
+	}
+	// every operator keeps its minimal probability
+	for _, operator := range variationOperators {
+		if rates.probability(operator) < rates.minProbability-1e-9 {
+			t.Errorf("probability of %s dropped below the minimum: %f", operator, rates.probability(operator))
+		}
+	}
+}
+
+func TestFastSubnetwork_ReductionKeepsSharedInteractions(t *testing.T) {
+	opt := mockNSGA()
+	opt.populationFromFile("", 7)
+	for _, network := range opt.Pt {
+		if len(network.SelectedPaths()) < 2 {
+			continue
+		}
+		network.reduction()
+		for _, path := range network.SelectedPaths() {
+			for interactionID := range path {
+				if !network.Interactions().Has(interactionID) {
+					t.Fatalf("reduction removed interaction %v of a remaining path", interactionID)
+				}
+			}
+		}
+	}
+}
+
+func TestVariationStatistics(t *testing.T) {
+	opt := mockNSGA()
+	children := make([]subnetwork, 3)
+	for i, operator := range []string{crossoverVariation, reductionVariation, reductionVariation} {
+		children[i] = newFastSubnetwork(opt)
+		children[i].setCrossoverOperator(uniformCrossover)
+		children[i].setVariationOperator(operator)
+	}
+	produced, survived := newVariationStatistics().countOffspring(children, children[2:])
+	if produced[reductionVariation] != 2 || survived[reductionVariation] != 1 || produced[crossoverVariation] != 1 {
+		t.Errorf("unexpected variation statistics: %v %v", produced, survived)
+	}
+}
@@ -0,0 +1,262 @@
+package optimization
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// names of the available crossover operators
+const (
+	alternatingCrossover   = arguments.AlternatingCrossover
+	uniformCrossover       = arguments.UniformCrossover
+	geneAnchoredCrossover  = arguments.GeneAnchoredCrossover
+	conditionWiseCrossover = arguments.ConditionWiseCrossover
+)
+
+// crossoverFunc creates a child from the paths of two parents, aiming for a child of size targetSize
+type crossoverFunc func(opt *NSGAOptimization, parentPathIDs [2][]PathID, targetSize int) subnetwork
+
+// crossoverOperators maps the operator names to their implementation
+var crossoverOperators = map[string]crossoverFunc{
+	alternatingCrossover:   (*NSGAOptimization).alternatingCrossover,
+	uniformCrossover:       (*NSGAOptimization).uniformCrossover,
+	geneAnchoredCrossover:  (*NSGAOptimization).geneAnchoredCrossover,
+	conditionWiseCrossover: (*NSGAOptimization).conditionWiseCrossover,
+}
+
+// operatorStatistics tracks, per operator, how many children were produced and how many survived selection
+// the operator of a child is its crossover operator, or its variation operator for the adaptive rates
+type operatorStatistics struct {
+	operatorOf func(network subnetwork) string
+	produced   map[string]int
+	survived   map[string]int
+}
+
+// newCrossoverStatistics tracks the success of the children of each crossover operator
+func newCrossoverStatistics() *operatorStatistics {
+	return &operatorStatistics{
+		operatorOf: subnetwork.CrossoverOperator,
+		produced:   make(map[string]int),
+		survived:   make(map[string]int),
+	}
+}
+
+// countOffspring counts the produced offspring and the offspring that made it into the selected population
+func (stats *operatorStatistics) countOffspring(offspring, selected []subnetwork) (produced, survived map[string]int) {
+	produced = make(map[string]int)
+	survived = make(map[string]int)
+	isSelected := make(map[subnetwork]struct{}, len(selected))
+	for _, network := range selected {
+		isSelected[network] = struct{}{}
+	}
+	for _, child := range offspring {
+		operator := stats.operatorOf(child)
+		if operator == "" {
+			continue
+		}
+		produced[operator]++
+		if _, ok := isSelected[child]; ok {
+			survived[operator]++
+		}
+	}
+	for operator, count := range produced {
+		stats.produced[operator] += count
+		stats.survived[operator] += survived[operator]
+	}
+	return produced, survived
+}
+
+// crossoverLogAttributes converts the statistics into logging attributes, sorted by operator name
+func crossoverLogAttributes(produced, survived map[string]int) []any {
+	operators := make([]string, 0, len(produced))
+	for operator := range produced {
+		operators = append(operators, operator)
+	}
+	sort.Strings(operators)
+	attributes := make([]any, 0, 2*len(operators))
+	for _, operator := range operators {
+		rate := float64(survived[operator]) / float64(produced[operator])
+		attributes = append(attributes,
+			operator,
+			fmt.Sprintf("%d/%d (%.3f)", survived[operator], produced[operator], rate),
+		)
+	}
+	return attributes
+}
+
+// crossover selects a random crossover operator among the requested ones and applies it
+func (opt *NSGAOptimization) crossover(parentPathIDs [2][]PathID, targetSize int) subnetwork {
+	names := opt.crossoverNames
+	if len(names) == 0 {
+		names = []string{alternatingCrossover}
+	}
+	name := names[rand.Intn(len(names))]
+	child := crossoverOperators[name](opt, parentPathIDs, targetSize)
+	if child.subnetworkSize() == 0 {
+		// never create empty subnetworks, fall back to alternating crossover
+		name = alternatingCrossover
+		child = opt.alternatingCrossover(parentPathIDs, targetSize)
+	}
+	child.setCrossoverOperator(name)
+	child.setVariationOperator(crossoverVariation)
+	return child
+}
+
+// addPathToChild adds the path to the child, unless the path was added before
+func (opt *NSGAOptimization) addPathToChild(child subnetwork, pathID PathID, addedPaths map[PathID]struct{}) {
+	if _, ok := addedPaths[pathID]; ok {
+		return
+	}
+	addedPaths[pathID] = struct{}{}
+	path := opt.pathRepositories.pathInteractionSetFromId(pathID)
+	child.addSelectedPath(pathID, path)
+	for interactionID := range path {
+		child.addInteraction(interactionID)
+	}
+}
+
+// alternatingCrossover alternately adds a random path of each parent to the child until the target size is reached
+func (opt *NSGAOptimization) alternatingCrossover(parentPathIDs [2][]PathID, targetSize int) subnetwork {
+	// track duplicates
+	addedPaths := make(map[PathID]struct{})
+
+	// initialize child
+	child := newFastSubnetwork(opt)
+
+	// perform crossover
+	currentParent := 0
+	for child.subnetworkSize() < targetSize {
+		if len(parentPathIDs[currentParent]) == 0 {
+			// only one parent with paths left, switch back
+			currentParent = 1 - currentParent
+		}
+		if len(parentPathIDs[currentParent]) == 0 {
+			// both parents have no paths left, break
+			break
+		}
+
+		// get random path from current parent and add to child
+		nPaths := len(parentPathIDs[currentParent])
+		idx := rand.Intn(nPaths)
+		pathID := parentPathIDs[currentParent][idx]
+
+		// delete this element from paths slice, by copying last element and then removing last element from slice
+		parentPathIDs[currentParent][idx] = parentPathIDs[currentParent][nPaths-1]
+		parentPathIDs[currentParent] = parentPathIDs[currentParent][:nPaths-1]
+
+		// add selected path to child, duplicates are skipped
+		opt.addPathToChild(child, pathID, addedPaths)
+
+		// switch parents
+		currentParent = 1 - currentParent
+	}
+	return child
+}
+
+// uniformCrossover inherits the paths shared by both parents,
+// every other path is inherited with a 50% chance until the target size is reached
+func (opt *NSGAOptimization) uniformCrossover(parentPathIDs [2][]PathID, targetSize int) subnetwork {
+	addedPaths := make(map[PathID]struct{})
+	child := newFastSubnetwork(opt)
+	// count in how many parents each path occurs
+	occurrences := make(map[PathID]int)
+	candidates := make([]PathID, 0, len(parentPathIDs[0])+len(parentPathIDs[1]))
+	for _, pathIDs := range parentPathIDs {
+		for _, pathID := range pathIDs {
+			if occurrences[pathID] == 0 {
+				candidates = append(candidates, pathID)
+			}
+			occurrences[pathID]++
+		}
+	}
+	// shared paths are always inherited
+	for _, pathID := range candidates {
+		if occurrences[pathID] > 1 {
+			opt.addPathToChild(child, pathID, addedPaths)
+		}
+	}
+	// remaining paths are inherited at random
+	shuffle(candidates)
+	for _, pathID := range candidates {
+		if child.subnetworkSize() >= targetSize {
+			break
+		}
+		if occurrences[pathID] == 1 && rand.Float64() < 0.5 {
+			opt.addPathToChild(child, pathID, addedPaths)
+		}
+	}
+	return child
+}
+
+// geneAnchoredCrossover samples a set of anchor genes from both parents.
+// All paths of the first parent touching an anchor gene are inherited,
+// the paths of the second parent not touching any anchor gene are added until the target size is reached.
+func (opt *NSGAOptimization) geneAnchoredCrossover(parentPathIDs [2][]PathID, targetSize int) subnetwork {
+	addedPaths := make(map[PathID]struct{})
+	child := newFastSubnetwork(opt)
+	// sample the anchor genes, each gene in the parents is selected with a 50% chance
+	anchors := make(map[types.GeneID]bool)
+	for _, pathIDs := range parentPathIDs {
+		for _, pathID := range pathIDs {
+			for interactionID := range opt.pathRepositories.pathInteractionSetFromId(pathID) {
+				for _, gene := range []types.GeneID{interactionID.From(), interactionID.To()} {
+					if _, ok := anchors[gene]; !ok {
+						anchors[gene] = rand.Float64() < 0.5
+					}
+				}
+			}
+		}
+	}
+	touchesAnchor := func(pathID PathID) bool {
+		for interactionID := range opt.pathRepositories.pathInteractionSetFromId(pathID) {
+			if anchors[interactionID.From()] || anchors[interactionID.To()] {
+				return true
+			}
+		}
+		return false
+	}
+	// inherit the anchored paths from the first parent
+	for _, pathID := range parentPathIDs[0] {
+		if touchesAnchor(pathID) {
+			opt.addPathToChild(child, pathID, addedPaths)
+		}
+	}
+	// complement with the other paths of the second parent
+	shuffle(parentPathIDs[1])
+	for _, pathID := range parentPathIDs[1] {
+		if child.subnetworkSize() >= targetSize {
+			break
+		}
+		if !touchesAnchor(pathID) {
+			opt.addPathToChild(child, pathID, addedPaths)
+		}
+	}
+	return child
+}
+
+// conditionWiseCrossover inherits all paths of each condition from a single, randomly chosen, parent
+func (opt *NSGAOptimization) conditionWiseCrossover(parentPathIDs [2][]PathID, _ int) subnetwork {
+	addedPaths := make(map[PathID]struct{})
+	child := newFastSubnetwork(opt)
+	// group the paths of both parents per condition
+	pathsPerCondition := make(map[PathID][2][]PathID)
+	for parent, pathIDs := range parentPathIDs {
+		for _, pathID := range pathIDs {
+			condition := pathID.ConditionKey()
+			paths := pathsPerCondition[condition]
+			paths[parent] = append(paths[parent], pathID)
+			pathsPerCondition[condition] = paths
+		}
+	}
+	// select one parent per condition
+	for _, paths := range pathsPerCondition {
+		for _, pathID := range paths[rand.Intn(2)] {
+			opt.addPathToChild(child, pathID, addedPaths)
+		}
+	}
+	return child
+}
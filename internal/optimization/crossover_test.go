@@ -0,0 +1,75 @@
+package optimization
+
+import (
+	"testing"
+)
+
+func pathIDsOf(network subnetwork) []PathID {
+	pathIDs := make([]PathID, 0, len(network.SelectedPaths()))
+	for pathID := range network.SelectedPaths() {
+		pathIDs = append(pathIDs, pathID)
+	}
+	return pathIDs
+}
+
+func TestCrossoverOperators(t *testing.T) {
+	opt := mockNSGA()
+	opt.populationFromFile("", 7)
+	parents := [2]subnetwork{opt.Pt[0], opt.Pt[len(opt.Pt)-1]}
+
+	for name := range crossoverOperators {
+		t.Run(name, func(t *testing.T) {
+			opt.crossoverNames = []string{name}
+			parentPathIDs := [2][]PathID{pathIDsOf(parents[0]), pathIDsOf(parents[1])}
+			child := opt.crossover(parentPathIDs, 20)
+			if child.subnetworkSize() == 0 {
+				t.Fatalf("crossover %s produced an empty child", name)
+			}
+			if child.CrossoverOperator() != name && child.CrossoverOperator() != alternatingCrossover {
+				t.Errorf("expected operator %s, got %s", name, child.CrossoverOperator())
+			}
+			// every path of the child is inherited from one of the parents
+			for pathID := range child.SelectedPaths() {
+				_, inFirst := parents[0].SelectedPaths()[pathID]
+				_, inSecond := parents[1].SelectedPaths()[pathID]
+				if !inFirst && !inSecond {
+					t.Errorf("crossover %s introduced path %d not present in the parents", name, pathID)
+				}
+			}
+			// every interaction of the child is part of a selected path
+			for interactionID := range child.Interactions() {
+				found := false
+				for _, path := range child.SelectedPaths() {
+					if path.Has(interactionID) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("crossover %s introduced interaction %v outside the selected paths", name, interactionID)
+				}
+			}
+		})
+	}
+}
+
+func TestCrossoverStatistics(t *testing.T) {
+	opt := mockNSGA()
+	children := make([]subnetwork, 3)
+	for i, operator := range []string{uniformCrossover, uniformCrossover, conditionWiseCrossover} {
+		children[i] = newFastSubnetwork(opt)
+		children[i].setCrossoverOperator(operator)
+	}
+	stats := newCrossoverStatistics()
+	produced, survived := stats.countOffspring(children, children[1:])
+	if produced[uniformCrossover] != 2 || survived[uniformCrossover] != 1 {
+		t.Errorf("unexpected uniform statistics: %d/%d", survived[uniformCrossover], produced[uniformCrossover])
+	}
+	if produced[conditionWiseCrossover] != 1 || survived[conditionWiseCrossover] != 1 {
+		t.Errorf("unexpected condition-wise statistics: %d/%d", survived[conditionWiseCrossover], produced[conditionWiseCrossover])
+	}
+	stats.countOffspring(children, nil)
+	if stats.produced[uniformCrossover] != 4 || stats.survived[uniformCrossover] != 1 {
+		t.Errorf("unexpected cumulative statistics: %d/%d", stats.survived[uniformCrossover], stats.produced[uniformCrossover])
+	}
+}
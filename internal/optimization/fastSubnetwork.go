@@ -47,6 +47,9 @@ func (network *fastSubnetwork) expansion() {
 }
 
 // reduction removes a random path from the subnetwork as nsga mutation operator
+// The interactions that the removed path shares with the remaining paths stay in the subnetwork, such that the
+// interactions remain the union of the selected paths. Like an expansion, a reduction invalidates the scores, which are
+// recomputed when the offspring is evaluated.
 func (network *fastSubnetwork) reduction() {
 
 	// never create empty subnetwork
@@ -63,10 +66,20 @@ func (network *fastSubnetwork) reduction() {
 	}
 	// select random path
 	p := pathIds[rand.Intn(len(pathIds))]
-	// delete interactions of this path
-	for i := range network.selectedPaths[p] {
-		network.interactionSet.Delete(i)
-	}
 	// delete path from network
+	removed := network.selectedPaths[p]
 	delete(network.selectedPaths, p)
+	// delete interactions of this path, unless they are shared with another selected path
+	for i := range removed {
+		network.interactionSet.Delete(i)
+	}
+	for _, path := range network.selectedPaths {
+		for i := range path {
+			if removed.Has(i) {
+				network.interactionSet.Set(i)
+			}
+		}
+	}
+	// invalidate scores
+	network.scores = nil
 }
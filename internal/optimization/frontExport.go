@@ -0,0 +1,153 @@
+package optimization
+
+import (
+	_ "embed"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/MarchalLab/gonetic/internal/wfg"
+)
+
+//go:embed front.schema.json
+var frontSchema []byte
+
+const frontFileName = "front.json"
+const frontSchemaFileName = "front.schema.json"
+
+// frontJSON is the structured export of the final front, described by front.schema.json
+type frontJSON struct {
+	Schema     string        `json:"$schema"`
+	Objectives []string      `json:"objectives"`
+	Networks   []networkJSON `json:"networks"`
+}
+
+type networkJSON struct {
+	File                    string            `json:"file"`
+	Size                    int               `json:"size"`
+	Interactions            []interactionJSON `json:"interactions"`
+	Scores                  []scoreJSON       `json:"scores"`
+	HypervolumeContribution float64           `json:"hypervolumeContribution"`
+}
+
+type interactionJSON struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Type       string `json:"type"`
+	Regulatory bool   `json:"regulatory"`
+}
+
+type scoreJSON struct {
+	Objective string  `json:"objective"`
+	Score     float64 `json:"score"`
+	Rank      int     `json:"rank"`
+}
+
+// objectiveNames names the objectives in the order of the scores
+func objectiveNames(objectiveTypes []objectiveType, pathTypes []string) []string {
+	names := make([]string, 0, len(objectiveTypes))
+	pathTypeIdx := 0
+	for _, objType := range objectiveTypes {
+		switch objType {
+		case networkSizeObjectiveType:
+			names = append(names, "network-size")
+		case sampleObjectiveType:
+			names = append(names, "sample-count")
+		case dDNNFObjectiveType:
+			names = append(names, pathTypes[pathTypeIdx])
+			pathTypeIdx++
+		}
+	}
+	return names
+}
+
+// scoreRanks computes for each network the rank of its score for the given objective, equal scores share a rank
+func scoreRanks(networks []subnetwork, scoreIdx int) []int {
+	scores := make([]float64, len(networks))
+	for i, network := range networks {
+		scores[i] = network.Scores()[scoreIdx]
+	}
+	sorted := append([]float64{}, scores...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+	ranks := make([]int, len(networks))
+	for i, score := range scores {
+		// the rank is one more than the number of strictly better scores
+		ranks[i] = 1 + sort.Search(len(sorted), func(j int) bool {
+			return sorted[j] <= score
+		})
+	}
+	return ranks
+}
+
+// writeFront writes the front, with its schema, to the optimization directory
+func (runner MORunner) writeFront(objectiveTypes []objectiveType, networks []subnetwork, files []string) {
+	objectives := objectiveNames(objectiveTypes, runner.PathTypes)
+	ranks := make([][]int, len(objectives))
+	for scoreIdx := range objectives {
+		ranks[scoreIdx] = scoreRanks(networks, scoreIdx)
+	}
+	contributions := wfg.Contributions(wfg.Front(convertToPoints(networks)))
+	front := frontJSON{
+		Schema:     frontSchemaFileName,
+		Objectives: objectives,
+		Networks:   make([]networkJSON, 0, len(networks)),
+	}
+	for i, network := range networks {
+		interactions := make([]interactionJSON, 0, network.interactionCount())
+		for interactionID := range network.Interactions() {
+			interactions = append(interactions, interactionJSON{
+				From:       string(runner.GeneIDMap.GetNameFromID(interactionID.From())),
+				To:         string(runner.GeneIDMap.GetNameFromID(interactionID.To())),
+				Type:       runner.InteractionStore.InteractionType(interactionID),
+				Regulatory: runner.InteractionStore.IsRegulatoryInteraction(interactionID),
+			})
+		}
+		sort.Slice(interactions, func(a, b int) bool {
+			if interactions[a].From != interactions[b].From {
+				return interactions[a].From < interactions[b].From
+			}
+			if interactions[a].To != interactions[b].To {
+				return interactions[a].To < interactions[b].To
+			}
+			return interactions[a].Type < interactions[b].Type
+		})
+		scores := make([]scoreJSON, len(objectives))
+		for scoreIdx, objective := range objectives {
+			scores[scoreIdx] = scoreJSON{
+				Objective: objective,
+				Score:     network.Scores()[scoreIdx],
+				Rank:      ranks[scoreIdx][i],
+			}
+		}
+		front.Networks = append(front.Networks, networkJSON{
+			File:                    files[i],
+			Size:                    network.subnetworkSize(),
+			Interactions:            interactions,
+			Scores:                  scores,
+			HypervolumeContribution: contributions[i],
+		})
+	}
+	content, err := json.MarshalIndent(front, "", "  ")
+	if err != nil {
+		runner.Error("Could not encode front", "err", err)
+		return
+	}
+	err = runner.WriteLinesToNewFile(filepath.Join(runner.directory, frontFileName), []string{string(content)})
+	if err != nil {
+		runner.Error("Could not write front", "err", err)
+		return
+	}
+	err = runner.WriteLinesToNewFile(filepath.Join(runner.directory, frontSchemaFileName), []string{string(frontSchema)})
+	if err != nil {
+		runner.Error("Could not write front schema", "err", err)
+	}
+}
+
+// convertToPoints converts the scores of the networks to points, keeping duplicates
+func convertToPoints(networks []subnetwork) []wfg.Point {
+	points := make([]wfg.Point, len(networks))
+	for i, network := range networks {
+		points[i] = wfg.ConvertToPoint(network)
+	}
+	return points
+}
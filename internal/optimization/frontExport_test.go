@@ -0,0 +1,184 @@
+package optimization
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func TestObjectiveNames(t *testing.T) {
+	objectiveTypes := []objectiveType{networkSizeObjectiveType, sampleObjectiveType, dDNNFObjectiveType, dDNNFObjectiveType}
+	got := objectiveNames(objectiveTypes, []string{"eqtl", "expression"})
+	want := []string{"network-size", "sample-count", "eqtl", "expression"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("objectiveNames() = %v, want %v", got, want)
+	}
+}
+
+func TestScoreRanks(t *testing.T) {
+	scores := []float64{0.5, 2, 0.5, 1}
+	networks := make([]subnetwork, len(scores))
+	for i, score := range scores {
+		networks[i] = newFastSubnetwork(nil)
+		networks[i].SetScores([]float64{score})
+	}
+	got := scoreRanks(networks, 0)
+	want := []int{3, 1, 3, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scoreRanks() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteFront(t *testing.T) {
+	args := arguments.NewCommon()
+	args.FileWriter = &fileio.FileWriter{Logger: slog.Default()}
+	args.GeneIDMap = types.NewGeneIDMap()
+	args.InteractionStore = types.NewInteractionStore()
+	args.PathTypes = []string{"mutation"}
+	args.InteractionStore.AddInteractionType("pp", false)
+	args.InteractionStore.AddInteractionType("pd", true)
+	a := args.GeneIDMap.SetName("A")
+	b := args.GeneIDMap.SetName("B")
+	c := args.GeneIDMap.SetName("C")
+	ab := types.FromToTypeToID(a, b, args.InteractionStore.GetInteractionTypeID("pp"))
+	bc := types.FromToTypeToID(b, c, args.InteractionStore.GetInteractionTypeID("pd"))
+
+	// a small front of two networks that do not dominate each other
+	small := newFastSubnetwork(nil)
+	small.addInteraction(ab)
+	small.SetScores([]float64{-1, 0.5})
+	large := newFastSubnetwork(nil)
+	large.addInteraction(ab)
+	large.addInteraction(bc)
+	large.SetScores([]float64{-2, 0.75})
+	runner := MORunner{Common: args, directory: t.TempDir()}
+	runner.writeFront(
+		[]objectiveType{networkSizeObjectiveType, dDNNFObjectiveType},
+		[]subnetwork{small, large},
+		[]string{"small.network", "large.network"},
+	)
+
+	// the front validates against the schema written next to it
+	var front, schema map[string]any
+	readJSON(t, filepath.Join(runner.directory, frontFileName), &front)
+	readJSON(t, filepath.Join(runner.directory, frontSchemaFileName), &schema)
+	validateJSONSchema(t, "front", front, schema, schema["$defs"].(map[string]any))
+
+	// the fields hold the networks in order
+	var got frontJSON
+	readJSON(t, filepath.Join(runner.directory, frontFileName), &got)
+	want := frontJSON{
+		Schema:     frontSchemaFileName,
+		Objectives: []string{"network-size", "mutation"},
+		Networks: []networkJSON{
+			{
+				File:         "small.network",
+				Size:         1,
+				Interactions: []interactionJSON{{From: "A", To: "B", Type: "pp", Regulatory: false}},
+				Scores: []scoreJSON{
+					{Objective: "network-size", Score: -1, Rank: 1},
+					{Objective: "mutation", Score: 0.5, Rank: 2},
+				},
+				HypervolumeContribution: got.Networks[0].HypervolumeContribution,
+			},
+			{
+				File: "large.network",
+				Size: 2,
+				Interactions: []interactionJSON{
+					{From: "A", To: "B", Type: "pp", Regulatory: false},
+					{From: "B", To: "C", Type: "pd", Regulatory: true},
+				},
+				Scores: []scoreJSON{
+					{Objective: "network-size", Score: -2, Rank: 2},
+					{Objective: "mutation", Score: 0.75, Rank: 1},
+				},
+				HypervolumeContribution: got.Networks[1].HypervolumeContribution,
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("writeFront() wrote %+v, want %+v", got, want)
+	}
+}
+
+func readJSON(t *testing.T, fileName string, value any) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("could not read %s: %v", fileName, err)
+	}
+	if err := json.Unmarshal(content, value); err != nil {
+		t.Fatalf("could not decode %s: %v", fileName, err)
+	}
+}
+
+// validateJSONSchema validates a decoded JSON value against the keywords used by front.schema.json, and reports
+// fields that the schema does not describe
+func validateJSONSchema(t *testing.T, path string, value any, schema map[string]any, defs map[string]any) {
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+	}
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			t.Errorf("%s: expected an object, got %T", path, value)
+			return
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				t.Errorf("%s: missing required field %s", path, name)
+			}
+		}
+		for name, field := range object {
+			property, ok := properties[name].(map[string]any)
+			if !ok {
+				t.Errorf("%s: field %s is not described by the schema", path, name)
+				continue
+			}
+			validateJSONSchema(t, path+"."+name, field, property, defs)
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			t.Errorf("%s: expected an array, got %T", path, value)
+			return
+		}
+		for i, item := range array {
+			validateJSONSchema(t, path+"["+strconv.Itoa(i)+"]", item, schema["items"].(map[string]any), defs)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			t.Errorf("%s: expected a string, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			t.Errorf("%s: expected a boolean, got %T", path, value)
+		}
+	case "number", "integer":
+		number, ok := value.(float64)
+		if !ok {
+			t.Errorf("%s: expected a number, got %T", path, value)
+			return
+		}
+		if schema["type"] == "integer" && number != math.Trunc(number) {
+			t.Errorf("%s: expected an integer, got %v", path, number)
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			t.Errorf("%s: %v is below the minimum %v", path, number, minimum)
+		}
+	default:
+		t.Errorf("%s: unsupported schema type %v", path, schema["type"])
+	}
+}
@@ -16,6 +16,8 @@ type genericSubnetwork struct {
 	selectedPaths      map[PathID]types.InteractionIDSet // pathId -> all interactions in path
 	nonDominationLevel int
 	crowdingDistance   float64
+	crossoverOperator  string // the crossover operator that created this network, empty if not created by crossover
+	variationOperator  string // the last variation operator (crossover, expansion or reduction) applied to this offspring
 	scores             []float64
 	opt                *NSGAOptimization
 }
@@ -59,6 +61,22 @@ func (network *genericSubnetwork) setCrowdingDistance(dist float64) {
 	network.crowdingDistance = dist
 }
 
+func (network *genericSubnetwork) CrossoverOperator() string {
+	return network.crossoverOperator
+}
+
+func (network *genericSubnetwork) setCrossoverOperator(operator string) {
+	network.crossoverOperator = operator
+}
+
+func (network *genericSubnetwork) VariationOperator() string {
+	return network.variationOperator
+}
+
+func (network *genericSubnetwork) setVariationOperator(operator string) {
+	network.variationOperator = operator
+}
+
 func (network *genericSubnetwork) SelectedPaths() map[PathID]types.InteractionIDSet {
 	return network.selectedPaths
 }
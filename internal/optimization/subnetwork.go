@@ -26,6 +26,10 @@ type subnetwork interface {
 	setNonDominationLevel(level int)
 	CrowdingDistance() float64
 	setCrowdingDistance(dist float64)
+	CrossoverOperator() string
+	setCrossoverOperator(operator string)
+	VariationOperator() string
+	setVariationOperator(operator string)
 	SelectedPaths() map[PathID]types.InteractionIDSet
 	Interactions() types.InteractionIDSet
 	interactionCount() int
@@ -28,13 +28,23 @@ func eqtl(pathType string, mutFileData, expressionFileData, differentialExpressi
 	expressionPerCondition := readers.MakeExpressionMap(args.Logger, args.GeneIDMap, expressionFileData, args.ExpressionWeightingMethod)
 	// make map of differentially expressed genes per condition
 	dePerCondition := readers.MakeGeneMap(args.GeneIDMap, differentialExpressionFileData)
+	pattern := compilePathPattern(args.Common)
+	// the direction of the expression change of each gene, which the net sign of the paths should agree with
+	var lfcPerCondition map[types.Condition]map[types.GeneID]float64
+	if args.SignConsistent {
+		if _, ok := expressionFileData.Headers["lfc"]; !ok {
+			args.Error("Expression data file does not contain an lfc header while sign-consistent paths are requested.")
+			panic("Cannot find sign-consistent paths")
+		}
+		lfcPerCondition = readers.MakeExpressionMap(args.Logger, args.GeneIDMap, expressionFileData, "lfc")
+	}
 
 	// Path Finding
 	args.Info("Start processing", "samples", len(conditions), "parallelism", args.NumCPU)
 	for condition := range conditions {
 		// create network for condition
 		if args.ExpressionWeightingMethod != "none" {
-			network = expressionNetwork(args.Expression, expressionPerCondition[condition], true)
+			network = expressionNetwork(args.Expression, expressionPerCondition[condition], true, relevanceSeeds(weightsPerGene))
 			if !args.SkipNetworkPrinting {
 				err := args.WriteStringLinerToFile(
 					string(condition),
@@ -65,9 +75,25 @@ func eqtl(pathType string, mutFileData, expressionFileData, differentialExpressi
 			pathDefinition = graph.SimplePathDefinition
 		}
 
+		if pattern != nil {
+			pathDefinition = pattern.Definition()
+		}
+		if args.SignConsistent {
+			lfc := lfcPerCondition[condition]
+			pathDefinition = graph.SignConsistentPathDefinition(pathDefinition, func(gene types.GeneID) types.InteractionSign {
+				return types.SignOf(lfc[gene])
+			})
+		}
+
 		// create search object
 		expander := graph.NewUpstreamExpander(network)
 		search := newPathFinder(args.Logger, expander, pathDefinition, sldCutoff)
+		if pattern != nil {
+			search = newPathFinder(args.Logger, graph.NewPatternExpander(network, pattern), pathDefinition, sldCutoff)
+		} else if args.BidirectionalSearch {
+			// meet in the middle: expand downstream from the mutated genes to join the upstream halves from the DE genes
+			search = newBidirectionalPathFinder(args.Logger, expander, graph.NewDownstreamExpander(network), pathDefinition, sldCutoff)
+		}
 		conditionPath := args.PathsFileWithName(pathType, fmt.Sprintf("%s.paths", condition))
 		run := newRunner(
 			args.Common,
@@ -89,7 +115,7 @@ func eqtl(pathType string, mutFileData, expressionFileData, differentialExpressi
 	// sync go routines
 	args.Sem.Wait()
 	// Combine the found .paths files into one large .paths file to optimize.
-	combinePathFiles(args.FileWriter, args.PathsDirectory(pathType), args.PathsFile(pathType))
+	combinePathFiles(args.FileWriter, args.PathCompression, args.PathsDirectory(pathType), args.PathsFile(pathType), readers.NewPathFileHeader(args.Common, args.QTLSpecific, args, pathType).Lines())
 	// Write the relevance scores
 	writeWeights(args.FileWriter, args.WeightsFile(pathType, ""), weightsPerGene)
 }
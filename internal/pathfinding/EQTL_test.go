@@ -2,10 +2,13 @@ package pathfinding
 
 import (
 	"log/slog"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
 	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
 	"github.com/MarchalLab/gonetic/internal/readers"
 )
 
@@ -70,6 +73,54 @@ func TestExpression(t *testing.T) {
 	expression("expression", expressionData, expressionData, args)
 }
 
+// TestExpressionRemovedGenes tests the expression path finding of several conditions with removed genes, the network
+// of a condition is made while the paths of the previous conditions are searched, run it with -race
+func TestExpressionRemovedGenes(t *testing.T) {
+	args := &arguments.Expression{
+		Common:                    commonArgs("testresult/expression-removed-genes"),
+		ExpressionWeightingMethod: "none",
+	}
+	args.BannedGenes = []string{"TP53", "FOX.*"}
+	args.MaxDegree = 3
+	args.NumCPU = 4
+	args.Init()
+	fileio.CreateEmptyDir(args.PathsDirectory("expression"))
+	expressionData := readers.ReadExpressionFile(args.Logger, "testdata/expression", "none")
+	if conditions := readers.LoadConditionData(expressionData, "condition"); len(conditions) < 2 {
+		t.Fatalf("expected several conditions, got %d", len(conditions))
+	}
+	interactionCount := args.InteractionStore.InteractionCount()
+	expression("expression", expressionData, expressionData, args)
+
+	// the networks of all conditions are without the removed genes
+	removed := readers.ReadRemovedGenes(args.RemovedGenesFile())
+	if len(removed) == 0 {
+		t.Fatalf("expected genes to be removed")
+	}
+	removedIDs := make(map[string]types.GeneName, len(removed))
+	for name := range removed {
+		removedIDs[strconv.FormatUint(uint64(args.GetIDFromName(name)), 10)] = name
+	}
+	for _, condition := range []string{"sample1", "sample2"} {
+		lines := fileio.ReadListFromFile(args.PathsFileWithName("expression", condition+".network"), true)
+		if len(lines) == 0 {
+			t.Errorf("expected the network of %s to have interactions", condition)
+		}
+		for _, line := range lines {
+			genes := strings.Split(line, ";")
+			for _, gene := range genes[:2] {
+				if name, ok := removedIDs[gene]; ok {
+					t.Errorf("expected the network of %s not to contain removed gene %s, got %q", condition, name, line)
+				}
+			}
+		}
+	}
+	// the shared interaction store keeps the interactions of the removed genes
+	if args.InteractionStore.InteractionCount() < interactionCount {
+		t.Errorf("expected the interaction store to keep its %d interactions, got %d", interactionCount, args.InteractionStore.InteractionCount())
+	}
+}
+
 func TestQTL(t *testing.T) {
 	args := &arguments.QTL{
 		Common:      commonArgs("testresult/qtl"),
@@ -85,3 +136,24 @@ func TestQTL(t *testing.T) {
 	)
 	qtl("qtl", mutationData, args.QTLSpecific, args.Common)
 }
+
+func TestCNA(t *testing.T) {
+	args := &arguments.QTL{
+		Common:      commonArgs("testresult/cna"),
+		QTLSpecific: &arguments.QTLSpecific{},
+	}
+	args.Init()
+	// create output folder
+	fileio.CreateEmptyDir(args.PathsDirectory("cna"))
+	// the data files
+	cnaData := readers.ReadCNAFile(
+		args.Logger,
+		"testdata/cna",
+	)
+	cna("cna", cnaData, args.QTLSpecific, args.Common)
+	// the paths connect the altered genes of both conditions
+	paths := fileio.ReadListFromFile(args.PathsFile("cna"), true)
+	if len(paths) == 0 {
+		t.Errorf("expected cna paths in %s", args.PathsFile("cna"))
+	}
+}
@@ -33,9 +33,14 @@ func expression(pathType string, expressionFileData, differentialExpressionFileD
 	// Note that the same gene can be an end point twice if it is mutated in two other lines. Doing so frequently mutated genes get selected more often as more overlapping paths will be found.
 	// A cutoff can be defined in order to avoid assessing mutated genes with very low weights as this takes up a lot of time while the found paths will not be relevant.
 	args.Info("Processing samples in parallel.", "samples", len(conditions), "parallelism", args.NumCPU)
+	var pattern *graph.PathPattern
 	for condition := range conditions {
 		// create network for condition
-		network := expressionNetwork(args, expressionPerCondition[condition], false)
+		network := expressionNetwork(args, expressionPerCondition[condition], false, expressionPerCondition[condition])
+		if pattern == nil {
+			// the interaction types in the pattern are known once the network is read
+			pattern = compilePathPattern(args.Common)
+		}
 		if !args.SkipNetworkPrinting {
 			args.Info(
 				"network size",
@@ -68,6 +73,10 @@ func expression(pathType string, expressionFileData, differentialExpressionFileD
 			expander = graph.NewRegulatoryUpDownstreamExpander(network)
 			pathDefinition = graph.BidirectionalRegulatoryUpDownstreamPathDefinition
 		}
+		if pattern != nil {
+			expander = graph.NewPatternExpander(network, pattern)
+			pathDefinition = pattern.Definition()
+		}
 		// The sldCutoff is the minimal probability a path must have in order to be retained. Setting this reduces the path
 		// finding time because paths through hubs do not need to be evaluated.
 		sldCutoff := args.SldCutoff
@@ -97,5 +106,5 @@ func expression(pathType string, expressionFileData, differentialExpressionFileD
 	// sync go routines
 	args.Sem.Wait()
 	// Combine the found .paths files into one large .paths file to optimize.
-	combinePathFiles(args.FileWriter, args.PathsDirectory(pathType), args.PathsFile(pathType))
+	combinePathFiles(args.FileWriter, args.PathCompression, args.PathsDirectory(pathType), args.PathsFile(pathType), readers.NewPathFileHeader(args.Common, nil, nil, pathType).Lines())
 }
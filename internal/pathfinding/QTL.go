@@ -54,19 +54,6 @@ func qtlPrep(pathType string, mutFileData readers.FileData, commonArgs *argument
 		"interactions", network.InteractionCount(),
 		"interaction types", len(network.InteractionTypes()),
 	)
-	if !commonArgs.SkipNetworkPrinting {
-		err := commonArgs.WriteStringLinerToFile(
-			"qtl",
-			commonArgs.PathsFileWithName(pathType, "qtl.network"),
-			network,
-		)
-		if err != nil {
-			commonArgs.Error(
-				"Failed to write QTL network",
-				"error", err,
-			)
-		}
-	}
 
 	// Setup path weighting values
 	mutatorOutlierValues := map[types.Condition]float64{}
@@ -91,6 +78,28 @@ func qtlPrep(pathType string, mutFileData readers.FileData, commonArgs *argument
 		frequencyDataWeighting,
 		mutatorOutlierValues,
 	)
+	network, sldCutoff := weightedQTLNetwork(pathType, commonArgs, network, weightsPerGene)
+	return network, sldCutoff, conditions, mutatedGenes, mutationPerCondition, weightsPerGene
+}
+
+// weightedQTLNetwork diffuses the relevance of the altered genes over the network when a propagation weighting method
+// is used, prints the network and determines the sldCutoff of the search
+func weightedQTLNetwork(pathType string, commonArgs *arguments.Common, network *graph.Network, weightsPerGene types.GeneConditionMap[float64]) (*graph.Network, float64) {
+	// the propagation weighting diffuses the relevance of the altered genes over the network
+	network = propagationWeighting(commonArgs, network, relevanceSeeds(weightsPerGene))
+	if !commonArgs.SkipNetworkPrinting {
+		err := commonArgs.WriteStringLinerToFile(
+			"qtl",
+			commonArgs.PathsFileWithName(pathType, "qtl.network"),
+			network,
+		)
+		if err != nil {
+			commonArgs.Error(
+				"Failed to write QTL network",
+				"error", err,
+			)
+		}
+	}
 
 	// The sldCutoff is the minimal probability a path must have in order to be retained.
 	// Setting this reduces the path finding time because paths through hubs do not need to be evaluated.
@@ -100,7 +109,7 @@ func qtlPrep(pathType string, mutFileData readers.FileData, commonArgs *argument
 	}
 	commonArgs.Info("sldCutoff", "sldCutoff", sldCutoff)
 
-	return network, sldCutoff, conditions, mutatedGenes, mutationPerCondition, weightsPerGene
+	return network, sldCutoff
 }
 
 func qtl(pathType string, mutFileData readers.FileData, qtlArgs *arguments.QTLSpecific, args *arguments.Common) {
@@ -115,23 +124,88 @@ func qtl(pathType string, mutFileData readers.FileData, qtlArgs *arguments.QTLSp
 
 	// Data preparation
 	network, sldCutoff, conditions, mutatedGenes, mutationPerCondition, weightsPerGene := qtlPrep(pathType, mutFileData, args, qtlArgs)
+	qtlSearch(pathType, args, qtlArgs, network, sldCutoff, conditions, mutatedGenes, mutationPerCondition, weightsPerGene)
+}
+
+// qtlSearch searches paths from the altered genes of each condition to the altered genes of the other conditions, and
+// combines them into the paths file of the path type. The altered genes are the tab separated gene name and condition.
+func qtlSearch(
+	pathType string,
+	args *arguments.Common,
+	qtlArgs *arguments.QTLSpecific,
+	network *graph.Network,
+	sldCutoff float64,
+	conditions types.ConditionSet,
+	mutatedGenes map[string]struct{},
+	mutationPerCondition map[types.Condition]types.GeneSet,
+	weightsPerGene types.GeneConditionMap[float64],
+) {
+	pattern := compilePathPattern(args)
+	// the network of each condition, which is the common network unless per-sample data is provided
+	networks := make(map[types.Condition]*graph.Network, len(conditions))
+	for condition := range conditions {
+		networks[condition] = network
+	}
+	if qtlArgs.SampleWeightingFile != "" {
+		sampleFileData := readers.ReadExpressionFile(args.Logger, qtlArgs.SampleWeightingFile, qtlArgs.SampleWeightingMethod)
+		sampleData := readers.MakeExpressionMap(args.Logger, args.GeneIDMap, sampleFileData, qtlArgs.SampleWeightingMethod)
+		networks = sampleNetworks(args, qtlArgs, network, conditions, sampleData)
+		if !args.SkipNetworkPrinting {
+			for condition, sampleNetwork := range networks {
+				if sampleNetwork == network {
+					continue
+				}
+				err := args.WriteStringLinerToFile(
+					string(condition),
+					args.PathsFileWithName(pathType, fmt.Sprintf("%s.network", condition)),
+					sampleNetwork,
+				)
+				if err != nil {
+					args.Error(
+						"Failed to write QTL sample network",
+						"error", err,
+						"condition", condition,
+					)
+				}
+			}
+		}
+	}
 	args.WriteGeneMapFile()
 	args.WriteInteractionTypeMapFile()
 
 	// Path Finding
 	// TODO: fix comments
 	// Build this specific run using the general run scheme
-	// Expand downstream. A simple path definition would be problematic as
-	expander := graph.NewDownstreamExpander(network)
-	// The path definition is simple as regulatory path is of no interest.
-	// The sldCutoff is the minimal probability a path must have in order to be retained. Setting this reduces the path
-	// finding time because paths through hubs do not need to be evaluated.
-	search := newPathFinder(args.Logger, expander, graph.SimplePathDefinition, sldCutoff)
+	// Conditions that share a network also share a search
+	searches := make(map[*graph.Network]pathFinder)
+	searchFor := func(network *graph.Network) pathFinder {
+		if search, ok := searches[network]; ok {
+			return search
+		}
+		if pattern != nil {
+			search := newPathFinder(args.Logger, graph.NewPatternExpander(network, pattern), pattern.Definition(), sldCutoff)
+			searches[network] = search
+			return search
+		}
+		// Expand downstream. A simple path definition would be problematic as
+		expander := graph.NewDownstreamExpander(network)
+		// The path definition is simple as regulatory path is of no interest.
+		// The sldCutoff is the minimal probability a path must have in order to be retained. Setting this reduces the path
+		// finding time because paths through hubs do not need to be evaluated.
+		search := newPathFinder(args.Logger, expander, graph.SimplePathDefinition, sldCutoff)
+		if args.BidirectionalSearch {
+			// meet in the middle: expand upstream from the end genes to join the downstream halves from the start genes
+			search = newBidirectionalPathFinder(args.Logger, expander, graph.NewUpstreamExpander(network), graph.SimplePathDefinition, sldCutoff)
+		}
+		searches[network] = search
+		return search
+	}
 
 	// Search for paths every time starting from a specific mutated gene from a specific line to the N-best mutated genes from OTHER lines. (So all lines (experiments) are used in one run here)
 	// Note that the same gene can be an end point twice if it is mutated in two other lines. Doing so frequently mutated genes get selected more often as more overlapping paths will be found.
 	// A cutoff can be defined in order to avoid assessing mutated genes with very low weights as this takes up a lot of time while the found paths will not be relevant.
 	args.Info("Start processing", "samples", len(conditions), "parallelism", args.NumCPU)
+	runsPerNetwork := make(map[*graph.Network][]runner)
 	for condition := range conditions {
 		// paths go from current condition to any other condition
 		endGenes := make(types.GeneSet)
@@ -154,13 +228,13 @@ func qtl(pathType string, mutFileData readers.FileData, qtlArgs *arguments.QTLSp
 			conditions,
 			types.ConditionSet{condition: {}},
 			weightsPerGene, // Add the weights for mutated genes in order to weigh the paths.
-			search,
+			searchFor(networks[condition]),
 			conditionPath,      // The name of the output files
 			args.PathLength,    // The maximum PathLength to be explored
 			args.BestPathCount, // The number of paths from a start node. In theory more is better but the optimization step gets harder in that case. 25 is a realistic value.
 			condition,          // Name of the strain from which a path is found. Needed as otherwise paths starting from the same gene would be overwritten in the optimization step.
 		)
-		run.findPaths(true, true)
+		runsPerNetwork[networks[condition]] = append(runsPerNetwork[networks[condition]], run)
 		if qtlArgs.WithinCondition {
 			// path finding object
 			withinConditionPath := args.PathsFileWithName(pathType, fmt.Sprintf("%s.within.paths", condition))
@@ -171,19 +245,30 @@ func qtl(pathType string, mutFileData readers.FileData, qtlArgs *arguments.QTLSp
 				types.ConditionSet{condition: {}},
 				types.ConditionSet{}, // don't have to exclude any, since only the current condition is allowed anyway
 				weightsPerGene,       // Add the weights for mutated genes in order to weigh the paths.
-				search,
+				searchFor(networks[condition]),
 				withinConditionPath, // The name of the output files
 				args.PathLength,     // The maximum PathLength to be explored
 				args.BestPathCount,  // The number of paths from a start node. In theory more is better but the optimization step gets harder in that case. 25 is a realistic value.
 				condition,           // Name of the strain from which a path is found. Needed as otherwise paths starting from the same gene would be overwritten in the optimization step.
 			)
-			withinRun.findPaths(true, true)
+			runsPerNetwork[networks[condition]] = append(runsPerNetwork[networks[condition]], withinRun)
+		}
+	}
+	for _, runs := range runsPerNetwork {
+		if args.BidirectionalSearch && pattern == nil {
+			for _, run := range runs {
+				run.findPaths(true, true)
+			}
+			continue
 		}
+		// the runs on the same network only differ in their allowed end conditions,
+		// so the search tree from each start gene is shared
+		findPathsShared(runs)
 	}
 	// sync go routines
 	args.Sem.Wait()
 	// Combine the found .paths files into one large .paths file to optimize.
-	combinePathFiles(args.FileWriter, args.PathsDirectory(pathType), args.PathsFile(pathType))
+	combinePathFiles(args.FileWriter, args.PathCompression, args.PathsDirectory(pathType), args.PathsFile(pathType), readers.NewPathFileHeader(args, qtlArgs, nil, pathType).Lines())
 	// Write the relevance scores
 	writeWeights(args.FileWriter, args.WeightsFile(pathType, ""), weightsPerGene)
 }
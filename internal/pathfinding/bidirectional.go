@@ -0,0 +1,198 @@
+package pathfinding
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// newBidirectionalPathFinder is a constructor for a pathFinder that meets in the middle:
+// the forward expander grows paths from the start genes, while the backward expander grows paths in the opposite
+// direction from the end genes. Both halves are joined on their shared end gene.
+func newBidirectionalPathFinder(
+	logger *slog.Logger,
+	expander, backwardExpander graph.PathExpander,
+	pathDefinition graph.PathDefinition,
+	sldCutoff float64,
+) pathFinder {
+	search := newPathFinder(logger, expander, pathDefinition, sldCutoff)
+	search.backwardExpander = backwardExpander
+	return search
+}
+
+// isBidirectional returns whether the search meets in the middle
+func (search pathFinder) isBidirectional() bool {
+	return search.backwardExpander != nil
+}
+
+// backwardIndex maps a meeting gene to the backward half paths ending in it
+// the start gene of a backward half path is an end gene of the full path
+type backwardIndex map[types.GeneID][]*graph.Path
+
+// forwardLength and backwardLength split a path of the given length in a forward and a backward half,
+// the forward half takes the extra interaction for odd lengths, such that every path has exactly one split
+func forwardLength(pathlength int) int {
+	return pathlength - backwardLength(pathlength)
+}
+
+func backwardLength(pathlength int) int {
+	return pathlength / 2
+}
+
+// buildBackwardIndex grows the backward half paths from all end genes that can be scored in an allowed condition.
+// Half paths that can never exceed the sldCutoff, even with the best possible forward half, are pruned.
+// The index is shared by all start genes of a run and is read only after construction.
+func (search pathFinder) buildBackwardIndex(
+	pathlength int,
+	endGenes types.GeneSet,
+	mutatedGenesMap types.GeneConditionMap[float64],
+	filteredConditions, excludedConditions types.ConditionSet,
+	maxFromScore float64,
+) backwardIndex {
+	index := make(backwardIndex)
+	maxLength := backwardLength(pathlength)
+	for endGene := range endGenes {
+		// the best score of the end gene in any allowed condition
+		maxToScore := maxMutationScore(
+			types.GeneConditionMap[float64]{endGene: mutatedGenesMap[endGene]},
+			filteredConditions,
+			excludedConditions,
+		)
+		if maxToScore == 0 {
+			continue
+		}
+		toVisit := []*graph.Path{graph.RootPath(endGene, 1)}
+		for len(toVisit) > 0 {
+			current := toVisit[len(toVisit)-1]
+			toVisit = toVisit[:len(toVisit)-1]
+			index[current.EndGene] = append(index[current.EndGene], current)
+			if current.Length >= maxLength {
+				continue
+			}
+			expansions, err := search.backwardExpander.Expand(current)
+			if err != nil {
+				search.Error("Error expanding backward path", "path", current, "err", err)
+				continue
+			}
+			for _, expansion := range expansions {
+				if expansion.Probability()*maxFromScore*maxToScore > search.sldCutoff {
+					toVisit = append(toVisit, expansion)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// joinHalves joins a forward half and a backward half on their shared end gene into a full path from the start gene.
+// ok is false if the halves do not form a valid path, e.g. because they share another gene.
+func (search pathFinder) joinHalves(
+	forward, backward *graph.Path,
+	from types.GeneID,
+	fromScore float64,
+) (*graph.Path, bool) {
+	interactions := make([]types.InteractionID, 0, forward.Length+backward.Length)
+	interactions = append(interactions, forward.Interactions()...)
+	backwardInteractions := slices.Clone(backward.Interactions())
+	slices.Reverse(backwardInteractions)
+	interactions = append(interactions, backwardInteractions...)
+	path, err := search.expander.CreatePathFrom(interactions, from, fromScore, 1)
+	if err != nil || path.Length != len(interactions) {
+		// loops are rejected while creating the path, which results in a shorter path
+		return nil, false
+	}
+	return path, true
+}
+
+// findNBestPathsQTLBidirectional finds the N best paths in the QTL case by meeting in the middle.
+// It performs the same best-first search with branch and bound as findNBestPathsQTL on the forward halves,
+// and joins every forward half with the precomputed backward halves ending in the same gene.
+func (search pathFinder) findNBestPathsQTLBidirectional(
+	pathlength, n int,
+	from types.GeneID,
+	conditionFromGene types.Condition,
+	mutatedGenesMap types.GeneConditionMap[float64],
+	filteredConditions, excludedConditions types.ConditionSet,
+	endGenes types.GeneSet,
+	startIsMutated bool,
+	backward backwardIndex,
+) ([]*graph.Path, int) {
+	cutoff := search.sldCutoff
+	toVisit := NewPriorityQueue[*graph.Path]()
+	results := NewReversePriorityQueue[*graph.Path]()
+
+	// Get the score of the mutation with the best score in the gene from which the path starts for the condition from which the path starts.
+	fromScore := 1.0
+	if startIsMutated {
+		fromMutationWeight, ok := mutatedGenesMap.Get(from, conditionFromGene)
+		if !ok {
+			return results.PopToReverseSlice(), 0
+		}
+		fromScore = fromMutationWeight
+	}
+	maxToScore := maxMutationScore(mutatedGenesMap, filteredConditions, excludedConditions)
+
+	// the bound of a forward half also bounds every full path containing it, since backward halves have probability <= 1
+	// its probability already includes the from score
+	boundProbability := func(path *graph.Path) float64 {
+		return path.Probability() * maxToScore
+	}
+
+	// BFS with BB on the forward halves
+	maxForwardLength := forwardLength(pathlength)
+	toVisit.Push(graph.RootPath(from, fromScore))
+	maxToVisitSize := toVisit.Len()
+	for !toVisit.Empty() {
+		maxToVisitSize = max(maxToVisitSize, toVisit.Len())
+		current := toVisit.Pop()
+		// branch and bound
+		if results.Len() >= n && results.Top().Probability() > boundProbability(current) {
+			break // no better path is possible
+		}
+		// join with the backward halves that make a correctly split path
+		for _, half := range backward[current.EndGene] {
+			if half.Length != current.Length && half.Length != current.Length-1 {
+				continue
+			}
+			if current.Length+half.Length == 0 {
+				continue
+			}
+			if current.Probability()*half.Probability()*maxToScore <= cutoff {
+				continue
+			}
+			path, ok := search.joinHalves(current, half, from, fromScore)
+			if !ok {
+				continue
+			}
+			_, isEndGene := endGenes[path.EndGene]
+			_, isMutatedGene := mutatedGenesMap[path.EndGene]
+			isNotFromGene := from != path.EndGene
+			isValidPath := search.pathDefinition(*path)
+			if isEndGene && isMutatedGene && isNotFromGene && isValidPath {
+				cutoff = search.addConditionallyScoredPaths(
+					path,
+					from,
+					fromScore,
+					n,
+					cutoff,
+					mutatedGenesMap,
+					filteredConditions,
+					excludedConditions,
+					results,
+				)
+			}
+		}
+		// construct next forward halves from current half
+		if current.Length < maxForwardLength {
+			children := search.expandPath(current, cutoff, boundProbability)
+			for _, child := range children {
+				if child.Probability() > cutoff {
+					toVisit.Push(child)
+				}
+			}
+		}
+	}
+	return results.PopToReverseSlice(), maxToVisitSize
+}
@@ -0,0 +1,92 @@
+package pathfinding
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+func pathSignatures(paths []*graph.Path) []string {
+	signatures := make([]string, 0, len(paths))
+	for _, path := range paths {
+		signatures = append(signatures, fmt.Sprintf("%v %s %.12f", path.Interactions(), path.EndCondition, path.Probability()))
+	}
+	slices.Sort(signatures)
+	return signatures
+}
+
+// TestBidirectionalSearch tests that meeting in the middle finds the same paths as the unidirectional search
+func TestBidirectionalSearch(t *testing.T) {
+	args := commonArgs("testresult/bidirectional")
+	args.TopologyWeightingAddition = "bayes"
+	args.Init()
+	network := makeNetwork(args, 0)
+
+	// every gene is mutated in two conditions, with different scores
+	mutated := make(types.GeneConditionMap[float64])
+	endGenes := make(types.GeneSet)
+	for gene := range network.Genes() {
+		mutated.Add(gene, "start", 1)
+		mutated.Add(gene, "end", 0.5+float64(gene%5)/10)
+		endGenes[gene] = struct{}{}
+	}
+	include := types.ConditionSet{"end": {}}
+	exclude := types.ConditionSet{"start": {}}
+
+	for _, pathLength := range []int{1, 2, 3, 4, 5} {
+		// request all paths, such that ties in the n best paths do not matter
+		n := 10000
+		t.Run(fmt.Sprintf("length %d", pathLength), func(t *testing.T) {
+			unidirectional := newPathFinder(args.Logger, graph.NewDownstreamExpander(network), graph.SimplePathDefinition, 0)
+			bidirectional := newBidirectionalPathFinder(args.Logger, graph.NewDownstreamExpander(network), graph.NewUpstreamExpander(network), graph.SimplePathDefinition, 0)
+			backward := bidirectional.buildBackwardIndex(pathLength, endGenes, mutated, include, exclude, 1)
+			for from := range endGenes {
+				expected, _ := unidirectional.findNBestPathsQTL(pathLength, n, from, "start", mutated, include, exclude, endGenes, true)
+				got, _ := bidirectional.findNBestPathsQTLBidirectional(pathLength, n, from, "start", mutated, include, exclude, endGenes, true, backward)
+				if !slices.Equal(pathSignatures(expected), pathSignatures(got)) {
+					t.Errorf("from %d: expected paths %v, got %v", from, pathSignatures(expected), pathSignatures(got))
+				}
+			}
+		})
+	}
+}
+
+// TestBidirectionalSearchScoredStart tests that meeting in the middle finds paths with the same probabilities as the
+// unidirectional search when the start genes score below 1 and only a few paths are requested
+func TestBidirectionalSearchScoredStart(t *testing.T) {
+	args := commonArgs("testresult/bidirectional")
+	args.TopologyWeightingAddition = "bayes"
+	args.Init()
+	network := makeNetwork(args, 0)
+
+	// every gene is mutated in two conditions, with scores below 1
+	mutated := make(types.GeneConditionMap[float64])
+	endGenes := make(types.GeneSet)
+	for gene := range network.Genes() {
+		mutated.Add(gene, "start", 0.2+float64(gene%4)/10)
+		mutated.Add(gene, "end", 0.5+float64(gene%5)/10)
+		endGenes[gene] = struct{}{}
+	}
+	include := types.ConditionSet{"end": {}}
+	exclude := types.ConditionSet{"start": {}}
+
+	pathLength := 4
+	for _, n := range []int{1, 3} {
+		t.Run(fmt.Sprintf("n %d", n), func(t *testing.T) {
+			unidirectional := newPathFinder(args.Logger, graph.NewDownstreamExpander(network), graph.SimplePathDefinition, 0)
+			bidirectional := newBidirectionalPathFinder(args.Logger, graph.NewDownstreamExpander(network), graph.NewUpstreamExpander(network), graph.SimplePathDefinition, 0)
+			backward := bidirectional.buildBackwardIndex(pathLength, endGenes, mutated, include, exclude, 1)
+			for from := range endGenes {
+				expected, _ := unidirectional.findNBestPathsQTL(pathLength, n, from, "start", mutated, include, exclude, endGenes, true)
+				got, _ := bidirectional.findNBestPathsQTLBidirectional(pathLength, n, from, "start", mutated, include, exclude, endGenes, true, backward)
+				// paths with equal probabilities may be exchanged when not all paths are requested
+				if !slices.Equal(pathProbabilities(expected), pathProbabilities(got)) {
+					t.Errorf("from %d: expected probabilities %v, got %v", from, pathProbabilities(expected), pathProbabilities(got))
+				}
+			}
+		})
+	}
+}
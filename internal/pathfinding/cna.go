@@ -0,0 +1,39 @@
+package pathfinding
+
+import (
+	"time"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/pathweight"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+// cna searches paths between the genes with a copy-number alteration in different conditions, like the QTL setting
+// does for mutated genes. The relevance of an altered gene follows from the amplitude and the focality of the alteration.
+func cna(pathType string, cnaFileData readers.FileData, qtlArgs *arguments.QTLSpecific, args *arguments.Common) {
+	args.Info("Running CNA pathfinding")
+
+	// timing
+	startTime := time.Now()
+	defer func() {
+		passedTime := time.Now().Sub(startTime).Seconds()
+		args.Info("Finished path finding", "seconds", passedTime)
+	}()
+
+	// Data preparation
+	conditions := readers.LoadConditionData(cnaFileData, "condition")
+	alteredGenes := readers.LoadData(cnaFileData, "gene name", "condition")
+	alterationPerCondition := readers.MakeGeneMap(args.GeneIDMap, cnaFileData)
+	network := qtlNetwork(args)
+	var cnaDataPerLine map[string]struct{}
+	if _, ok := cnaFileData.Headers["focality"]; ok {
+		cnaDataPerLine = readers.LoadData(cnaFileData, "gene name", "copy number", "condition", "focality")
+	} else {
+		cnaDataPerLine = readers.LoadData(cnaFileData, "gene name", "copy number", "condition")
+	}
+	weightsPerGene := pathweight.CNARelevanceScorePerGene(args.Logger, args.GeneIDMap, cnaDataPerLine)
+	network, sldCutoff := weightedQTLNetwork(pathType, args, network, weightsPerGene)
+
+	// Path Finding
+	qtlSearch(pathType, args, qtlArgs, network, sldCutoff, conditions, alteredGenes, alterationPerCondition, weightsPerGene)
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
 	"github.com/MarchalLab/gonetic/internal/common/fileio"
 	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
 	"github.com/MarchalLab/gonetic/internal/readers"
 )
 
 func Run(
 	mutationFileData readers.FileData,
+	cnaFileData readers.FileData,
 	expressionFileData readers.FileData,
 	differentialExpressionFileData readers.FileData,
 	expressionArgs *arguments.Expression,
@@ -27,38 +29,71 @@ func Run(
 	}()
 	for _, pathType := range commonArgs.PathTypes {
 		fileio.CreateEmptyDir(commonArgs.PathsDirectory(pathType))
-		switch pathType {
-		case "eqtl":
-			eqtl(
-				pathType,
-				mutationFileData,
-				expressionFileData,
-				differentialExpressionFileData,
-				eqtlArgs,
-			)
-			break
-		case "expression":
-			expression(
-				pathType,
-				expressionFileData,
-				differentialExpressionFileData,
-				expressionArgs,
-			)
-			break
-		case "mutation":
-			qtl(
-				pathType,
-				mutationFileData,
-				qtlArgs,
-				commonArgs,
-			)
-			break
-		}
+		runPathType(
+			pathType,
+			mutationFileData,
+			cnaFileData,
+			expressionFileData,
+			differentialExpressionFileData,
+			expressionArgs,
+			qtlArgs,
+			commonArgs,
+			eqtlArgs,
+		)
 	}
 	commonArgs.WriteGeneMapFile()
 	commonArgs.WriteInteractionTypeMapFile()
 }
 
+// runPathType finds the paths of a single path type
+func runPathType(
+	pathType string,
+	mutationFileData readers.FileData,
+	cnaFileData readers.FileData,
+	expressionFileData readers.FileData,
+	differentialExpressionFileData readers.FileData,
+	expressionArgs *arguments.Expression,
+	qtlArgs *arguments.QTLSpecific,
+	commonArgs *arguments.Common,
+	eqtlArgs *arguments.EQTL,
+) {
+	switch pathType {
+	case "eqtl":
+		eqtl(
+			pathType,
+			mutationFileData,
+			expressionFileData,
+			differentialExpressionFileData,
+			eqtlArgs,
+		)
+		break
+	case "expression":
+		expression(
+			pathType,
+			expressionFileData,
+			differentialExpressionFileData,
+			expressionArgs,
+		)
+		break
+	case "mutation":
+		qtl(
+			pathType,
+			mutationFileData,
+			qtlArgs,
+			commonArgs,
+		)
+		break
+	case "cna":
+		cna(
+			pathType,
+			cnaFileData,
+			qtlArgs,
+			commonArgs,
+		)
+		break
+	}
+}
+
 func writeWeights(fw *fileio.FileWriter, outputFileName string, weightsPerGene types.GeneConditionMap[float64]) {
 	var lines []string
 	for gene, conditionMap := range weightsPerGene {
@@ -73,9 +108,32 @@ func writeWeights(fw *fileio.FileWriter, outputFileName string, weightsPerGene t
 	}
 }
 
-func combinePathFiles(fw *fileio.FileWriter, outputFolder, outputFileName string) {
-	var lines []string
-	err := filepath.Walk(outputFolder, func(path string, info os.FileInfo, err error) error {
+// combinePathFiles streams the lines of all (compressed) .paths files in the output folder to a single file with the given compression
+// the combined file starts with the given header
+func combinePathFiles(fw *fileio.FileWriter, compression, outputFolder, outputFileName string, header []string) {
+	output, err := fileio.CreateCompressedFile(outputFileName, compression)
+	if err != nil {
+		fw.Error("error creating combined paths file", "err", err)
+		return
+	}
+	writer := bufio.NewWriter(output)
+	// the written lines are flushed and the file is closed, also when writing fails
+	defer func() {
+		if err := writer.Flush(); err != nil {
+			fw.Error("error writing combined paths to file", "err", err)
+		}
+		if err := output.Close(); err != nil {
+			fw.Error("error closing combined paths file", "err", err)
+		}
+	}()
+	for _, line := range header {
+		if _, err := writer.WriteString(line + "\n"); err != nil {
+			fw.Error("error writing path file header", "err", err)
+			return
+		}
+	}
+	first := true
+	err = filepath.Walk(outputFolder, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -83,29 +141,51 @@ func combinePathFiles(fw *fileio.FileWriter, outputFolder, outputFileName string
 			// skip directories
 			return nil
 		}
-		if filepath.Ext(path) != ".paths" {
+		if filepath.Ext(fileio.TrimCompressionExtension(path)) != ".paths" {
 			// skip files that are not paths-files
 			return nil
 		}
 		// copy paths
-		inputFile, err := os.Open(path)
-		defer inputFile.Close()
+		inputFile, err := fileio.OpenDecompressedFile(path)
 		if err != nil {
 			return err
 		}
+		defer inputFile.Close()
 		scanner := bufio.NewScanner(inputFile)
 		for scanner.Scan() {
-			line := scanner.Text()
-			lines = append(lines, line)
+			// no newline after the last line
+			if !first {
+				if err := writer.WriteByte('\n'); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := writer.WriteString(scanner.Text()); err != nil {
+				return err
+			}
 		}
-		return nil
+		return scanner.Err()
 	})
 	if err != nil {
 		fw.Error("error walking the path", "err", err)
-		return
 	}
-	err = fw.WriteLinesToNewFile(outputFileName, lines)
+}
+
+// compilePathPattern compiles the --path-pattern, nil means that the default path definition of the path type is used
+func compilePathPattern(args *arguments.Common) *graph.PathPattern {
+	if args.PathPattern == "" {
+		return nil
+	}
+	pattern, err := graph.CompilePathPattern(args.PathPattern)
 	if err != nil {
-		fw.Error("error writing combined paths to file", "err", err)
+		args.Error("Invalid argument --path-pattern", "pattern", args.PathPattern, "err", err)
+		panic("Invalid path pattern")
+	}
+	for _, typeName := range pattern.InteractionTypes() {
+		if _, ok := args.InteractionStore.InteractionTypes().NameToID()[typeName]; !ok {
+			args.Warn("Path pattern refers to an interaction type that is not in the network", "type", typeName)
+		}
 	}
+	args.Info("path pattern", "pattern", pattern, "direction", pattern.Direction())
+	return pattern
 }
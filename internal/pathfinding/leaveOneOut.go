@@ -0,0 +1,119 @@
+package pathfinding
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+// RunLeaveOneOut finds the paths of the input data from which the dropped condition is removed. For the path types in
+// which the paths of a condition only depend on the data of that condition, the paths files of the other conditions
+// are reused from the run on the full data. The paths of the other path types are searched again.
+func RunLeaveOneOut(
+	fullArgs *arguments.Common,
+	dropped types.Condition,
+	mutationFileData readers.FileData,
+	cnaFileData readers.FileData,
+	expressionFileData readers.FileData,
+	differentialExpressionFileData readers.FileData,
+	expressionArgs *arguments.Expression,
+	qtlArgs *arguments.QTLSpecific,
+	commonArgs *arguments.Common,
+	eqtlArgs *arguments.EQTL,
+) {
+	for _, pathType := range commonArgs.PathTypes {
+		fileio.CreateEmptyDir(commonArgs.PathsDirectory(pathType))
+		if conditionLocalPaths(pathType, commonArgs, qtlArgs) && reuseConditionPaths(fullArgs, commonArgs, qtlArgs, eqtlArgs, pathType, dropped) {
+			commonArgs.Info("Reused the paths of the full data", "pathType", pathType, "dropped", dropped)
+			continue
+		}
+		runPathType(
+			pathType,
+			mutationFileData,
+			cnaFileData,
+			expressionFileData,
+			differentialExpressionFileData,
+			expressionArgs,
+			qtlArgs,
+			commonArgs,
+			eqtlArgs,
+		)
+	}
+	commonArgs.WriteGeneMapFile()
+	commonArgs.WriteInteractionTypeMapFile()
+}
+
+// conditionLocalPaths returns whether the paths of a condition only depend on the data of that condition. The paths of
+// the mutation path type end in the mutated genes of the other conditions, and the weights of the mutated genes depend
+// on all conditions when frequency increases, functional scores or the mutator correction are used. The eqtl networks
+// are weighted with propagation seeds over all conditions unless the degree weighting is used.
+func conditionLocalPaths(pathType string, commonArgs *arguments.Common, qtlArgs *arguments.QTLSpecific) bool {
+	switch pathType {
+	case "expression":
+		return true
+	case "eqtl":
+		return !qtlArgs.FreqIncrease && !qtlArgs.FuncScore && !qtlArgs.Correction &&
+			topologyWeightingMethod(commonArgs) == editor.TopologyWeightingDegree
+	default:
+		return false
+	}
+}
+
+// reuseConditionPaths copies the paths files of all but the dropped condition from the full data, and combines them.
+// It returns false when the full data has no paths of the path type.
+func reuseConditionPaths(fullArgs, args *arguments.Common, qtlArgs *arguments.QTLSpecific, eqtlArgs *arguments.EQTL, pathType string, dropped types.Condition) bool {
+	if fullArgs.PathCompression != args.PathCompression {
+		return false
+	}
+	if _, err := os.Stat(fullArgs.PathsFile(pathType)); err != nil {
+		return false
+	}
+	entries, err := os.ReadDir(fullArgs.PathsDirectory(pathType))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := fileio.TrimCompressionExtension(entry.Name())
+		if entry.IsDir() || filepath.Ext(name) != ".paths" || pathsFileCondition(name) == dropped {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(fullArgs.PathsDirectory(pathType), entry.Name()))
+		if err != nil {
+			args.Error("Failed to read paths of the full data", "error", err, "file", entry.Name())
+			return false
+		}
+		if err := os.WriteFile(filepath.Join(args.PathsDirectory(pathType), entry.Name()), content, 0644); err != nil {
+			args.Error("Failed to write reused paths", "error", err, "file", entry.Name())
+			panic("Cannot write reused paths")
+		}
+	}
+	combinePathFiles(args.FileWriter, args.PathCompression, args.PathsDirectory(pathType), args.PathsFile(pathType), readers.NewPathFileHeader(args, qtlArgs, eqtlArgs, pathType).Lines())
+	// the relevance scores of the dropped condition are not used
+	if _, err := os.Stat(fullArgs.WeightsFile(pathType, "")); err == nil {
+		weights := make([]string, 0)
+		for _, line := range fileio.ReadListFromFile(fullArgs.WeightsFile(pathType, ""), false) {
+			split := strings.Split(line, ";")
+			if len(split) == 3 && types.Condition(split[1]) == dropped {
+				continue
+			}
+			weights = append(weights, line)
+		}
+		if err := args.WriteLinesToNewFile(args.WeightsFile(pathType, ""), weights); err != nil {
+			args.Error("error writing weights to file", "err", err)
+		}
+	}
+	return true
+}
+
+// pathsFileCondition returns the condition of a per-condition paths file name
+func pathsFileCondition(name string) types.Condition {
+	name = strings.TrimSuffix(name, ".paths")
+	name = strings.TrimSuffix(name, ".within")
+	return types.Condition(name)
+}
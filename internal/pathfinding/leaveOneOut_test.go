@@ -0,0 +1,76 @@
+package pathfinding
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+func TestRunLeaveOneOut(t *testing.T) {
+	expressionArgs := func(output string) *arguments.Expression {
+		args := &arguments.Expression{
+			Common:                    commonArgs(output),
+			ExpressionWeightingMethod: "none",
+		}
+		args.PathTypes = []string{"expression"}
+		args.Init()
+		fileio.CreateEmptyDir(args.PathsDirectory("expression"))
+		return args
+	}
+	// the paths of the full data
+	full := expressionArgs("testresult/leave-one-out/full")
+	for _, condition := range []string{"sample1", "sample2"} {
+		fileName := filepath.Join(full.PathsDirectory("expression"), condition+".paths")
+		if err := full.WriteLinesToNewFile(fileName, []string{"path of " + condition}); err != nil {
+			t.Fatalf("failed to write paths: %v", err)
+		}
+	}
+	combinePathFiles(full.FileWriter, full.PathCompression, full.PathsDirectory("expression"), full.PathsFile("expression"), nil)
+
+	dropped := expressionArgs("testresult/leave-one-out/sample1")
+	expressionData := readers.DropCondition("sample1", readers.ReadExpressionFile(full.Logger, "testdata/expression", "none"))[0]
+	RunLeaveOneOut(full.Common, "sample1", readers.FileData{}, readers.FileData{}, expressionData, expressionData, dropped, &arguments.QTLSpecific{}, dropped.Common, &arguments.EQTL{})
+
+	// the paths of the dropped condition are removed, the paths of the other conditions are reused
+	if _, err := os.Stat(filepath.Join(dropped.PathsDirectory("expression"), "sample1.paths")); err == nil {
+		t.Errorf("expected the paths of the dropped condition to be removed")
+	}
+	reused := fileio.ReadListFromFile(filepath.Join(dropped.PathsDirectory("expression"), "sample2.paths"), true)
+	if len(reused) != 1 || reused[0] != "path of sample2" {
+		t.Errorf("expected the paths of the other conditions to be reused, got %v", reused)
+	}
+	combined := strings.Join(fileio.ReadListFromFile(dropped.PathsFile("expression"), true), "\n")
+	if strings.Contains(combined, "sample1") || !strings.Contains(combined, "path of sample2") {
+		t.Errorf("expected the combined paths to contain the reused paths only, got %q", combined)
+	}
+}
+
+func TestConditionLocalPaths(t *testing.T) {
+	testCases := []struct {
+		pathType string
+		method   string
+		qtlArgs  arguments.QTLSpecific
+		expected bool
+	}{
+		{"expression", "rwr", arguments.QTLSpecific{}, true},
+		{"eqtl", "", arguments.QTLSpecific{}, true},
+		{"eqtl", "degree", arguments.QTLSpecific{}, true},
+		{"eqtl", "degree", arguments.QTLSpecific{FreqIncrease: true}, false},
+		// the propagation seeds are the highest relevance scores over all conditions
+		{"eqtl", "rwr", arguments.QTLSpecific{}, false},
+		{"eqtl", "heat", arguments.QTLSpecific{}, false},
+		{"mutation", "degree", arguments.QTLSpecific{}, false},
+	}
+	for _, testCase := range testCases {
+		args := arguments.NewCommon()
+		args.TopologyWeightingMethod = testCase.method
+		if got := conditionLocalPaths(testCase.pathType, args, &testCase.qtlArgs); got != testCase.expected {
+			t.Errorf("conditionLocalPaths(%s, %q, %+v) = %v, expected %v", testCase.pathType, testCase.method, testCase.qtlArgs, got, testCase.expected)
+		}
+	}
+}
@@ -1,9 +1,18 @@
 package pathfinding
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"log/slog"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
 	"github.com/MarchalLab/gonetic/internal/common/types"
 	"github.com/MarchalLab/gonetic/internal/editor"
 	"github.com/MarchalLab/gonetic/internal/graph"
@@ -19,18 +28,26 @@ func makeNetwork(args *arguments.Common, minEdgeScore float64) *graph.Network {
 		"Invalid argument --topology-weighting-addition %s",
 	)
 	// initialize network with initial probability of 0 or 1 for each edge
-	nwr := readers.NewInitialNetworkReader(args.Logger, args.GeneIDMap)
+	nwr := readers.NewInitialNetworkReader(args.Logger, args.GeneIDMap).WithFormat(args.NetworkFormat, args.NetworkTypeMapping).WithSources(args.NetworkSources)
 	network := nwr.NewNetworkFromFiles(args.NetworkFiles, args.BannedNetworkFiles, false, true)
+	// remove the banned genes and the hubs, with all their interactions, before weighting
+	removed := removedGenes(args, network)
+	network = editor.RemoveGenes(network, removed)
 	// There are no undirected edges in the network, undirected edges are represented as two directed edges.
 	// There are no duplicates in the network. Undirected interactions which are each other's reverse can not exist, since `from <lex to` by design.
 	// There are no self edges in the network.
-	// Weight on network topology (sigmoidal)
-	if !skipWeighting {
-		network = editor.NetworkWeighting(
-			editor.NewSigmoidalDegreeWeight(nwr.NewNetworkFromFiles(args.NetworkFiles, args.BannedNetworkFiles, false, true), 0.01).Score,
-			weightingAddition,
-			network,
+	// Weight on network topology (sigmoidal), propagation weighting needs the data and is done by propagationWeighting
+	if !skipWeighting && topologyWeightingMethod(args) == editor.TopologyWeightingDegree {
+		weight, err := editor.NewSigmoidalCentralityWeight(
+			editor.RemoveGenes(nwr.NewNetworkFromFiles(args.NetworkFiles, args.BannedNetworkFiles, false, true), removed),
+			hubCentrality(args),
+			0.01,
 		)
+		if err != nil {
+			args.Error("Invalid argument --hub-centrality", "error", err)
+			panic("Cannot weight the network")
+		}
+		network = editor.NetworkWeighting(weight.Score, weightingAddition, network)
 	}
 	// remove low scoring edges
 	if minEdgeScore > 0.0 {
@@ -39,8 +56,157 @@ func makeNetwork(args *arguments.Common, minEdgeScore float64) *graph.Network {
 	return network
 }
 
-func expressionNetwork(args *arguments.Expression, data map[types.GeneID]float64, isEQTL bool) *graph.Network {
+// removal reasons of genes that are removed from the network
+const (
+	removedBanned = "banned"
+	removedHub    = "hub"
+)
+
+// removedGenes determines the genes that are removed from the network because they match --banned-genes or interact
+// with more than --max-degree other genes. The removed genes are logged and written to the removed genes file.
+func removedGenes(args *arguments.Common, network *graph.Network) types.GeneSet {
+	if len(args.BannedGenes) == 0 && args.MaxDegree <= 0 {
+		return nil
+	}
+	reasons := make(map[types.GeneID]string)
+	if args.MaxDegree > 0 {
+		for gene := range editor.HubGenes(network, args.MaxDegree) {
+			reasons[gene] = removedHub
+		}
+	}
+	patterns := bannedGenePatterns(args)
+	for gene := range network.Genes() {
+		name := string(args.GetNameFromID(gene))
+		for _, pattern := range patterns {
+			if pattern.MatchString(name) {
+				reasons[gene] = removedBanned
+				break
+			}
+		}
+	}
+	genes := make(types.GeneSet, len(reasons))
+	lines := make([]string, 0, len(reasons))
+	for gene, reason := range reasons {
+		genes[gene] = struct{}{}
+		lines = append(lines, fmt.Sprintf("%s\t%s", args.GetNameFromID(gene), reason))
+	}
+	slices.Sort(lines)
+	args.Info("Removed genes from the network", "count", len(lines), "genes", lines)
+	if args.OutputFolder != "" {
+		err := args.WriteLinesToNewFile(args.RemovedGenesFile(), []string{"#gene\treason"}, lines)
+		if err != nil {
+			args.Error("Failed to write removed genes", "error", err, "file", args.RemovedGenesFile())
+		}
+	}
+	return genes
+}
+
+// bannedGenePatterns compiles the values of --banned-genes, which are gene names, regular expressions or files with
+// one name or regular expression per line, into regular expressions that match whole gene names
+func bannedGenePatterns(args *arguments.Common) []*regexp.Regexp {
+	expressions := make([]string, 0, len(args.BannedGenes))
+	for _, value := range args.BannedGenes {
+		if info, err := os.Stat(value); err == nil && !info.IsDir() {
+			for _, line := range fileio.ReadListFromFile(value, true) {
+				if line = strings.TrimSpace(line); len(line) > 0 && !strings.HasPrefix(line, "#") {
+					expressions = append(expressions, line)
+				}
+			}
+			continue
+		}
+		expressions = append(expressions, value)
+	}
+	patterns := make([]*regexp.Regexp, 0, len(expressions))
+	for _, expression := range expressions {
+		pattern, err := regexp.Compile("^(?:" + expression + ")$")
+		if err != nil {
+			args.Error("Invalid argument --banned-genes", "value", expression, "error", err)
+			panic("Cannot remove banned genes")
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// topologyWeightingMethod returns the topology weighting method, which is the degree weighting by default
+func topologyWeightingMethod(args *arguments.Common) string {
+	if args.TopologyWeightingMethod == "" {
+		return editor.TopologyWeightingDegree
+	}
+	return args.TopologyWeightingMethod
+}
+
+// hubCentrality returns the centrality that the degree topology weighting penalizes, which is the out-degree by default
+func hubCentrality(args *arguments.Common) string {
+	if args.HubCentrality == "" {
+		return editor.CentralityOutDegree
+	}
+	return args.HubCentrality
+}
+
+// propagationWeighting weights the network on its topology by diffusing the seed scores of the genes over the network.
+// The network is returned unchanged when the degree weighting is used.
+func propagationWeighting(args *arguments.Common, network *graph.Network, seeds map[types.GeneID]float64) *graph.Network {
+	method := topologyWeightingMethod(args)
+	if method == editor.TopologyWeightingDegree {
+		return network
+	}
+	weightingAddition, skipWeighting := editor.WeightingAddition(
+		args.Logger,
+		editor.FromOnly,
+		args.TopologyWeightingAddition,
+		"Invalid argument --topology-weighting-addition %s",
+	)
+	if skipWeighting {
+		return network
+	}
+	var weight editor.PropagationWeight
+	switch method {
+	case editor.TopologyWeightingRWR:
+		weight = editor.NewRandomWalkWeight(network, seeds, args.PropagationRestart, 0.01)
+	case editor.TopologyWeightingHeat:
+		weight = editor.NewHeatKernelWeight(network, seeds, args.HeatKernelTime, 0.01)
+	default:
+		args.Error("Invalid argument --topology-weighting-method", "method", method)
+		return network
+	}
+	if !hasSeedInNetwork(network, seeds) {
+		// e.g. the expression data is not used as seeds with --expression-weighting-method none
+		args.Warn("No propagation seeds in the network, every gene gets the same propagation score", "method", method, "seeds", len(seeds))
+	}
+	args.Info("propagation weighting", "method", method, "seeds", len(seeds))
+	network = editor.NetworkWeighting(weight.Score, weightingAddition, network)
+	// remove low scoring edges after weighting
+	return editor.RemoveLowScoringEdges(network, args.MinEdgeScore)
+}
+
+// hasSeedInNetwork returns whether a gene of the network has a nonzero seed score
+func hasSeedInNetwork(network *graph.Network, seeds map[types.GeneID]float64) bool {
+	genes := network.Genes()
+	for gene, score := range seeds {
+		if _, ok := genes[gene]; ok && score != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// relevanceSeeds are the highest relevance scores of the genes over the conditions
+func relevanceSeeds(weightsPerGene types.GeneConditionMap[float64]) map[types.GeneID]float64 {
+	seeds := make(map[types.GeneID]float64, len(weightsPerGene))
+	for gene, weights := range weightsPerGene {
+		for _, weight := range weights {
+			seeds[gene] = math.Max(seeds[gene], weight)
+		}
+	}
+	return seeds
+}
+
+// expressionNetwork creates the network of a condition, the topology is weighted with the seeds when a propagation
+// weighting method is used
+func expressionNetwork(args *arguments.Expression, data map[types.GeneID]float64, isEQTL bool, seeds map[types.GeneID]float64) *graph.Network {
 	network := makeNetwork(args.Common, args.MinEdgeScore)
+	network = propagationWeighting(args.Common, network, seeds)
 	if args.ExpressionWeightingMethod != "none" {
 		network = expressionWeighting(network, args, data, isEQTL)
 	}
@@ -66,30 +232,56 @@ func expressionWeighting(
 	if isEQTL {
 		weightTarget = editor.ToOnly
 	}
-	var weightingAddition, skipWeighting = editor.WeightingAddition(
+	return geneWeighting(
 		args.Logger,
+		network,
+		data,
 		weightTarget,
+		args.ExpressionWeightingMethod,
 		args.ExpressionWeightingAddition,
-		"Invalid argument --expression-weighting-addition %s",
+		args.ExpressionWeightingDefault,
+		args.PrintGeneScoreMap,
+		"--expression-weighting",
+	)
+}
+
+// geneWeighting weights the network with gene scores derived from the data, flagPrefix names the flags in error messages
+func geneWeighting(
+	logger *slog.Logger,
+	network *graph.Network,
+	data map[types.GeneID]float64,
+	weightTarget editor.WeightTarget,
+	method, addition string,
+	defaultProbability float64,
+	printGeneScoreMap bool,
+	flagPrefix string,
+) *graph.Network {
+	var weightingAddition, skipWeighting = editor.WeightingAddition(
+		logger,
+		weightTarget,
+		addition,
+		"Invalid argument "+flagPrefix+"-addition %s",
 	)
 	if skipWeighting {
 		return network
 	}
 	// determine weighting method based on settings
 	var weightingMethod func(*slog.Logger, map[types.GeneID]float64, float64) editor.ExpressionWeight
-	switch args.ExpressionWeightingMethod {
+	switch method {
 	case "lfc":
 		weightingMethod = editor.NewLfcExpressionWeight
 	case "zscore":
 		weightingMethod = editor.NewZscoreExpressionWeight
+	case "activity":
+		weightingMethod = editor.NewActivityWeight
 	default:
-		args.Error("Invalid argument --expression-weighting-method", "ExpressionWeightingMethod", args.ExpressionWeightingMethod)
+		logger.Error("Invalid argument "+flagPrefix+"-method", "method", method)
 		return network
 	}
 	// compute gene weights
-	weights := weightingMethod(args.Logger, data, args.ExpressionWeightingDefault)
+	weights := weightingMethod(logger, data, defaultProbability)
 	// print weights
-	if args.PrintGeneScoreMap {
+	if printGeneScoreMap {
 		weights.PrintScoreMap()
 	}
 	// weight network
@@ -101,6 +293,23 @@ func expressionWeighting(
 	return network
 }
 
+// NetworkGenes returns the sorted names of the genes with an interaction in the network used for path finding, from
+// which the banned genes and the hubs are removed
+func NetworkGenes(args *arguments.Common) []string {
+	genes := make(types.GeneSet)
+	for interaction := range makeNetwork(args, args.MinEdgeScore).Interactions() {
+		from, to := interaction.FromTo()
+		genes[from] = struct{}{}
+		genes[to] = struct{}{}
+	}
+	names := make([]string, 0, len(genes))
+	for gene := range genes {
+		names = append(names, string(args.GetNameFromID(gene)))
+	}
+	slices.Sort(names)
+	return names
+}
+
 func qtlNetwork(args *arguments.Common) *graph.Network {
 	network := makeNetwork(args, args.MinEdgeScore)
 	args.Info(
@@ -111,3 +320,59 @@ func qtlNetwork(args *arguments.Common) *graph.Network {
 	)
 	return network
 }
+
+// sampleNetworks creates the network of every condition by weighting the base network with the data of the condition.
+// Conditions without data use the base network, and conditions with the same data share a single network.
+func sampleNetworks(
+	args *arguments.Common,
+	qtlArgs *arguments.QTLSpecific,
+	base *graph.Network,
+	conditions types.ConditionSet,
+	dataPerCondition map[types.Condition]map[types.GeneID]float64,
+) map[types.Condition]*graph.Network {
+	networks := make(map[types.Condition]*graph.Network, len(conditions))
+	cache := make(map[string]*graph.Network)
+	for condition := range conditions {
+		data := dataPerCondition[condition]
+		if len(data) == 0 {
+			networks[condition] = base
+			continue
+		}
+		key := geneDataKey(data)
+		if network, ok := cache[key]; ok {
+			networks[condition] = network
+			continue
+		}
+		network := geneWeighting(
+			args.Logger,
+			base,
+			data,
+			editor.BothBayes,
+			qtlArgs.SampleWeightingMethod,
+			qtlArgs.SampleWeightingAddition,
+			qtlArgs.SampleWeightingDefault,
+			false,
+			"--sample-weighting",
+		)
+		// remove low scoring edges after weighting
+		network = editor.RemoveLowScoringEdges(network, args.MinEdgeScore)
+		cache[key] = network
+		networks[condition] = network
+	}
+	args.Info("sample networks", "conditions", len(conditions), "weighted networks", len(cache))
+	return networks
+}
+
+// geneDataKey identifies the gene data, such that conditions with the same data can share a network
+func geneDataKey(data map[types.GeneID]float64) string {
+	genes := make([]types.GeneID, 0, len(data))
+	for gene := range data {
+		genes = append(genes, gene)
+	}
+	slices.Sort(genes)
+	hash := sha256.New()
+	for _, gene := range genes {
+		fmt.Fprintf(hash, "%d\t%s\n", gene, strconv.FormatFloat(data[gene], 'g', -1, 64))
+	}
+	return string(hash.Sum(nil))
+}
@@ -0,0 +1,209 @@
+package pathfinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/graph"
+	"github.com/MarchalLab/gonetic/internal/pathweight"
+	"github.com/MarchalLab/gonetic/internal/powerlaws"
+)
+
+const (
+	networkStatsFileName   = "network-stats.json"
+	networkDegreesFileName = "network-degrees.tsv"
+)
+
+// networkStatsJSON is the report of the network statistics
+type networkStatsJSON struct {
+	NetworkFiles           []string              `json:"networkFiles"`
+	Genes                  int                   `json:"genes"`
+	Interactions           int                   `json:"interactions"`
+	RegulatoryInteractions int                   `json:"regulatoryInteractions"`
+	InteractionTypes       map[string]int        `json:"interactionTypes"`
+	Components             componentsJSON        `json:"components"`
+	Degrees                degreesJSON           `json:"degrees"`
+	PowerLaw               *powerLawJSON         `json:"powerLaw"`
+	SldCutoffPrediction    float64               `json:"sldCutoffPrediction"`
+	TopologyWeighting      topologyWeightingJSON `json:"topologyWeighting"`
+}
+
+type componentsJSON struct {
+	Count   int   `json:"count"`
+	Largest int   `json:"largest"`
+	Sizes   []int `json:"sizes"`
+}
+
+type degreesJSON struct {
+	MaxOut  int     `json:"maxOut"`
+	MaxIn   int     `json:"maxIn"`
+	MeanOut float64 `json:"meanOut"`
+}
+
+type powerLawJSON struct {
+	Centrality      string  `json:"centrality"`
+	Exponent        float64 `json:"exponent"`
+	XMin            int64   `json:"xMin"`
+	KSStatistic     float64 `json:"ksStatistic"`
+	InflectionPoint float64 `json:"inflectionPoint"`
+}
+
+type topologyWeightingJSON struct {
+	Method   string `json:"method"`
+	Addition string `json:"addition"`
+}
+
+// WriteNetworkStats loads the network as the path finding does, and writes a report of its statistics, the power law
+// fit of the topology weighting and the predicted search tree cutoff to the output folder, together with a table of the
+// degree distribution. Propagation weighting needs the input data, so the rwr and heat methods are not applied.
+func WriteNetworkStats(args *arguments.Common) {
+	network := makeNetwork(args, args.MinEdgeScore)
+	outDegrees, inDegrees := geneDegrees(network)
+	stats := networkStatsJSON{
+		NetworkFiles:        args.NetworkFiles,
+		Genes:               len(outDegrees),
+		Interactions:        network.InteractionCount(),
+		InteractionTypes:    make(map[string]int),
+		Components:          components(network),
+		Degrees:             degreeSummary(outDegrees, inDegrees),
+		SldCutoffPrediction: pathweight.SldCutoffPrediction(network),
+		TopologyWeighting: topologyWeightingJSON{
+			Method:   topologyWeightingMethod(args),
+			Addition: args.TopologyWeightingAddition,
+		},
+	}
+	for id := range *network.Probabilities() {
+		stats.InteractionTypes[network.InteractionType(id)]++
+		if network.IsRegulatoryInteraction(id) {
+			stats.RegulatoryInteractions++
+		}
+	}
+	// the power law is fitted on the network before weighting, as the degree topology weighting does
+	unweightedArgs := *args
+	unweightedArgs.TopologyWeightingAddition = "none"
+	fit, err := editor.FitHubCentrality(makeNetwork(&unweightedArgs, 0), hubCentrality(args))
+	switch {
+	case err != nil:
+		args.Error("Invalid argument --hub-centrality", "error", err)
+		panic("Cannot compute network statistics")
+	case len(fit.Collection) > 0:
+		stats.PowerLaw = &powerLawJSON{
+			Centrality:      hubCentrality(args),
+			Exponent:        fit.PowerLaw.Exponent(),
+			XMin:            fit.PowerLaw.XMin(),
+			KSStatistic:     fit.PowerLaw.DiscreteKSTest(powerlaws.CumulativeCounts(fit.Collection)),
+			InflectionPoint: fit.InflectionPoint,
+		}
+	}
+	args.Info("network stats",
+		"genes", stats.Genes,
+		"interactions", stats.Interactions,
+		"components", stats.Components.Count,
+		"sldCutoffPrediction", stats.SldCutoffPrediction,
+	)
+
+	content, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		args.Error("Could not encode network stats", "err", err)
+		return
+	}
+	statsFile := filepath.Join(args.OutputFolder, networkStatsFileName)
+	if err := args.WriteLinesToNewFile(statsFile, []string{string(content)}); err != nil {
+		args.Error("Could not write network stats", "err", err, "file", statsFile)
+	}
+	degreesFile := filepath.Join(args.OutputFolder, networkDegreesFileName)
+	if err := args.WriteLinesToNewFile(degreesFile, degreeTable(outDegrees, inDegrees)); err != nil {
+		args.Error("Could not write network degrees", "err", err, "file", degreesFile)
+	}
+}
+
+// geneDegrees counts the outgoing and incoming interactions of the genes of the network
+func geneDegrees(network *graph.Network) (map[types.GeneID]int, map[types.GeneID]int) {
+	outDegrees := make(map[types.GeneID]int)
+	inDegrees := make(map[types.GeneID]int)
+	for id := range *network.Probabilities() {
+		outDegrees[id.From()]++
+		inDegrees[id.To()]++
+		// genes without outgoing or incoming interactions have degree 0
+		outDegrees[id.To()] += 0
+		inDegrees[id.From()] += 0
+	}
+	return outDegrees, inDegrees
+}
+
+func degreeSummary(outDegrees, inDegrees map[types.GeneID]int) degreesJSON {
+	summary := degreesJSON{}
+	total := 0
+	for gene, degree := range outDegrees {
+		summary.MaxOut = max(summary.MaxOut, degree)
+		summary.MaxIn = max(summary.MaxIn, inDegrees[gene])
+		total += degree
+	}
+	if len(outDegrees) > 0 {
+		summary.MeanOut = float64(total) / float64(len(outDegrees))
+	}
+	return summary
+}
+
+// degreeTable counts the genes per out-degree, in-degree and total degree
+func degreeTable(outDegrees, inDegrees map[types.GeneID]int) []string {
+	outCounts, inCounts, totalCounts := make(map[int]int), make(map[int]int), make(map[int]int)
+	degrees := make(map[int]struct{})
+	for gene, outDegree := range outDegrees {
+		inDegree := inDegrees[gene]
+		outCounts[outDegree]++
+		inCounts[inDegree]++
+		totalCounts[outDegree+inDegree]++
+		degrees[outDegree] = struct{}{}
+		degrees[inDegree] = struct{}{}
+		degrees[outDegree+inDegree] = struct{}{}
+	}
+	sorted := make([]int, 0, len(degrees))
+	for degree := range degrees {
+		sorted = append(sorted, degree)
+	}
+	slices.Sort(sorted)
+	lines := make([]string, 0, len(sorted)+1)
+	lines = append(lines, "#degree\tout\tin\ttotal")
+	for _, degree := range sorted {
+		lines = append(lines, fmt.Sprintf("%d\t%d\t%d\t%d", degree, outCounts[degree], inCounts[degree], totalCounts[degree]))
+	}
+	return lines
+}
+
+// components computes the sizes of the weakly connected components of the network, largest first
+func components(network *graph.Network) componentsJSON {
+	parent := make(map[types.GeneID]types.GeneID)
+	var find func(gene types.GeneID) types.GeneID
+	find = func(gene types.GeneID) types.GeneID {
+		if _, ok := parent[gene]; !ok {
+			parent[gene] = gene
+		}
+		if parent[gene] != gene {
+			parent[gene] = find(parent[gene])
+		}
+		return parent[gene]
+	}
+	for id := range *network.Probabilities() {
+		parent[find(id.From())] = find(id.To())
+	}
+	sizes := make(map[types.GeneID]int)
+	for gene := range parent {
+		sizes[find(gene)]++
+	}
+	result := componentsJSON{Count: len(sizes), Sizes: make([]int, 0, len(sizes))}
+	for _, size := range sizes {
+		result.Sizes = append(result.Sizes, size)
+	}
+	slices.Sort(result.Sizes)
+	slices.Reverse(result.Sizes)
+	if len(result.Sizes) > 0 {
+		result.Largest = result.Sizes[0]
+	}
+	return result
+}
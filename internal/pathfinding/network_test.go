@@ -0,0 +1,240 @@
+package pathfinding
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/graph"
+	"github.com/MarchalLab/gonetic/internal/pathweight"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+// TestSampleNetworks tests that conditions with the same data share a weighted network
+func TestSampleNetworks(t *testing.T) {
+	args := commonArgs("testresult/samples")
+	args.TopologyWeightingAddition = "bayes"
+	args.Init()
+	network := makeNetwork(args, 0)
+	qtlArgs := &arguments.QTLSpecific{
+		SampleWeightingMethod:   "activity",
+		SampleWeightingAddition: "mean",
+	}
+
+	// c0 and c1 have the same activities, c2 has other activities and c3 has none
+	active := make(map[types.GeneID]float64)
+	inactive := make(map[types.GeneID]float64)
+	for gene := range network.Genes() {
+		active[gene] = 1
+		inactive[gene] = 0.1
+	}
+	conditions := types.ConditionSet{"c0": {}, "c1": {}, "c2": {}, "c3": {}}
+	data := map[types.Condition]map[types.GeneID]float64{
+		"c0": active,
+		"c1": active,
+		"c2": inactive,
+	}
+	networks := sampleNetworks(args, qtlArgs, network, conditions, data)
+
+	if networks["c0"] != networks["c1"] {
+		t.Errorf("expected conditions with the same data to share a network")
+	}
+	if networks["c0"] == networks["c2"] {
+		t.Errorf("expected conditions with other data to have another network")
+	}
+	if networks["c3"] != network {
+		t.Errorf("expected a condition without data to use the common network")
+	}
+	if networks["c2"].InteractionCount() == 0 {
+		t.Fatalf("expected the weighted network to keep interactions")
+	}
+	// lower activities lower the edge probabilities
+	for interaction, probability := range *networks["c0"].Probabilities() {
+		if lower := networks["c2"].Probabilities().GetProbability(interaction); lower >= probability {
+			t.Errorf("expected a lower probability than %f for interaction %d, got %f", probability, interaction, lower)
+		}
+	}
+}
+
+// TestWriteRandomizedNetworks tests that randomized networks can be used as the network of a run
+func TestWriteRandomizedNetworks(t *testing.T) {
+	args := commonArgs("testresult/null-networks")
+	args.Init()
+	fileio.CreateEmptyDir(args.OutputFolder)
+	network := makeNetwork(args, 0)
+
+	fileNames := WriteRandomizedNetworks(args, 2, args.OutputFolder)
+	if len(fileNames) != 2 {
+		t.Fatalf("expected 2 randomized networks, got %d", len(fileNames))
+	}
+	for _, fileName := range fileNames {
+		randomizedArgs := *args
+		randomizedArgs.NetworkFiles = []string{fileName}
+		randomized := makeNetwork(&randomizedArgs, 0)
+		if randomized.InteractionCount() != network.InteractionCount() {
+			t.Errorf("expected %d interactions in %s, got %d", network.InteractionCount(), fileName, randomized.InteractionCount())
+		}
+		for gene := range network.Genes() {
+			if degree(network, gene) != degree(randomized, gene) {
+				t.Errorf("expected degree %d for gene %d in %s, got %d", degree(network, gene), gene, fileName, degree(randomized, gene))
+			}
+		}
+	}
+}
+
+// degree counts the interactions of the gene in the network
+func degree(network *graph.Network, gene types.GeneID) int {
+	count := 0
+	for id := range *network.Probabilities() {
+		if id.From() == gene || id.To() == gene {
+			count++
+		}
+	}
+	return count
+}
+
+// TestRemovedGenes tests that banned genes and hubs are removed from the network with all their interactions
+func TestRemovedGenes(t *testing.T) {
+	args := commonArgs("testresult/removed-genes")
+	args.BannedGenes = []string{"TP53", "FOX.*"}
+	args.MaxDegree = 3
+	args.Init()
+	fileio.CreateEmptyDir(args.OutputFolder)
+	fullArgs := *args
+	fullArgs.BannedGenes = nil
+	fullArgs.MaxDegree = 0
+	full := makeNetwork(&fullArgs, 0)
+	hubs := editor.HubGenes(full, args.MaxDegree)
+	if len(hubs) == 0 {
+		t.Fatalf("expected the network to have hubs")
+	}
+	network := makeNetwork(args, 0)
+
+	removed := readers.ReadRemovedGenes(args.RemovedGenesFile())
+	for _, name := range []types.GeneName{"TP53", "FOXA1"} {
+		if removed[name] != removedBanned {
+			t.Errorf("expected gene %s to be removed as banned, got %q", name, removed[name])
+		}
+	}
+	for gene := range hubs {
+		if reason, ok := removed[args.GetNameFromID(gene)]; !ok || (reason != removedHub && reason != removedBanned) {
+			t.Errorf("expected hub %s to be removed, got %q", args.GetNameFromID(gene), reason)
+		}
+	}
+	for name := range removed {
+		gene := args.GetIDFromName(name)
+		if degree(network, gene) != 0 {
+			t.Errorf("expected removed gene %s to have no interactions, got %d", name, degree(network, gene))
+		}
+		if _, ok := network.Genes()[gene]; ok {
+			t.Errorf("expected removed gene %s not to be in the network", name)
+		}
+	}
+	if network.InteractionCount() == 0 || network.InteractionCount() >= full.InteractionCount() {
+		t.Errorf("expected fewer interactions than %d, got %d", full.InteractionCount(), network.InteractionCount())
+	}
+}
+
+// TestNetworkGenes tests that only the genes of the network, without the removed genes, are returned
+func TestNetworkGenes(t *testing.T) {
+	args := commonArgs("")
+	args.BannedGenes = []string{"TP53"}
+	args.Init()
+	args.GeneIDMap.SetName("NOT_IN_NETWORK")
+	genes := NetworkGenes(args)
+	if len(genes) == 0 || !slices.IsSorted(genes) {
+		t.Fatalf("expected sorted network genes, got %v", genes)
+	}
+	for _, name := range []string{"TP53", "NOT_IN_NETWORK"} {
+		if slices.Contains(genes, name) {
+			t.Errorf("expected gene %s not to be drawn", name)
+		}
+	}
+	if len(genes) >= len(args.GeneIDMap.NameToID()) {
+		t.Errorf("expected fewer than the %d indexed genes, got %d", len(args.GeneIDMap.NameToID()), len(genes))
+	}
+}
+
+// TestWriteNetworkStats tests that the network statistics are consistent with the network
+func TestWriteNetworkStats(t *testing.T) {
+	args := commonArgs("testresult/network-stats")
+	args.TopologyWeightingAddition = "bayes"
+	args.Init()
+	fileio.CreateEmptyDir(args.OutputFolder)
+	WriteNetworkStats(args)
+
+	content, err := os.ReadFile(filepath.Join(args.OutputFolder, networkStatsFileName))
+	if err != nil {
+		t.Fatalf("failed to read network stats: %v", err)
+	}
+	stats := networkStatsJSON{}
+	if err := json.Unmarshal(content, &stats); err != nil {
+		t.Fatalf("failed to decode network stats: %v", err)
+	}
+	network := makeNetwork(args, 0)
+	if stats.Interactions != network.InteractionCount() {
+		t.Errorf("expected %d interactions, got %d", network.InteractionCount(), stats.Interactions)
+	}
+	typeCount := 0
+	for _, count := range stats.InteractionTypes {
+		typeCount += count
+	}
+	if typeCount != stats.Interactions {
+		t.Errorf("expected the interaction type counts to sum to %d, got %d", stats.Interactions, typeCount)
+	}
+	componentSize := 0
+	for _, size := range stats.Components.Sizes {
+		componentSize += size
+	}
+	if stats.Components.Count != len(stats.Components.Sizes) || componentSize != stats.Genes || stats.Components.Largest != stats.Components.Sizes[0] {
+		t.Errorf("expected the components to cover the %d genes, got %+v", stats.Genes, stats.Components)
+	}
+	if stats.PowerLaw == nil || stats.PowerLaw.InflectionPoint <= 0 || stats.PowerLaw.Exponent <= 0 {
+		t.Errorf("expected a power law fit, got %+v", stats.PowerLaw)
+	}
+	if stats.SldCutoffPrediction != pathweight.SldCutoffPrediction(network) {
+		t.Errorf("expected the predicted search tree cutoff %f, got %f", pathweight.SldCutoffPrediction(network), stats.SldCutoffPrediction)
+	}
+
+	// every gene is counted once per degree column
+	degrees := fileio.ReadListFromFile(filepath.Join(args.OutputFolder, networkDegreesFileName), true)
+	counts := make([]int, 3)
+	for _, line := range degrees[1:] {
+		tokens := strings.Split(line, "\t")
+		for i := range counts {
+			count, _ := strconv.Atoi(tokens[i+1])
+			counts[i] += count
+		}
+	}
+	for i, count := range counts {
+		if count != stats.Genes {
+			t.Errorf("expected degree column %d to count %d genes, got %d", i, stats.Genes, count)
+		}
+	}
+}
+
+func TestHasSeedInNetwork(t *testing.T) {
+	network := smallNetwork(map[[2]types.GeneID]float64{{1, 2}: 0.5})
+	testCases := []struct {
+		seeds    map[types.GeneID]float64
+		expected bool
+	}{
+		{map[types.GeneID]float64{}, false},
+		{map[types.GeneID]float64{1: 0}, false},
+		{map[types.GeneID]float64{3: 1}, false},
+		{map[types.GeneID]float64{2: -0.5}, true},
+	}
+	for _, testCase := range testCases {
+		if got := hasSeedInNetwork(network, testCase.seeds); got != testCase.expected {
+			t.Errorf("hasSeedInNetwork(%v) = %v, expected %v", testCase.seeds, got, testCase.expected)
+		}
+	}
+}
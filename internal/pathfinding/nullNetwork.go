@@ -0,0 +1,64 @@
+package pathfinding
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/editor"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// nullNetworkSwaps is the average number of swaps per interaction, which is sufficient to randomize the network
+const nullNetworkSwaps = 10
+
+// WriteRandomizedNetworks writes count degree-preserving randomizations of the network to the directory, and returns
+// their file names. The unweighted network is randomized, such that the randomized networks can be weighted like the
+// network itself when they are used as the network files of a run.
+func WriteRandomizedNetworks(args *arguments.Common, count int, directory string) []string {
+	unweightedArgs := *args
+	unweightedArgs.TopologyWeightingAddition = "none"
+	network := makeNetwork(&unweightedArgs, 0)
+	fileNames := make([]string, 0, count)
+	for i := range count {
+		randomized, swaps := editor.RandomizeNetwork(network, nullNetworkSwaps)
+		args.Info("randomized network", "network", i, "interactions", randomized.InteractionCount(), "swaps", swaps)
+		fileName := filepath.Join(directory, fmt.Sprintf("network-%d.txt", i))
+		if err := args.WriteLinesToNewFile(fileName, networkFileLines(args, randomized)); err != nil {
+			args.Error("Failed to write randomized network", "error", err, "file", fileName)
+			panic("Cannot write randomized network")
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	return fileNames
+}
+
+// networkFileLines formats the network as a network file, undirected interactions are written as two directed interactions
+func networkFileLines(args *arguments.Common, network *graph.Network) []string {
+	typeNames := make(map[string]bool)
+	lines := make([]string, 0, network.InteractionCount())
+	for id, probability := range *network.Probabilities() {
+		typeName := args.InteractionStore.InteractionType(id)
+		typeNames[typeName] = args.InteractionStore.IsRegulatoryInteraction(id)
+		lines = append(lines, fmt.Sprintf(
+			"%s\t%s\t%s\tdirected\t%s",
+			args.GeneIDMap.GetNameFromID(id.From()),
+			args.GeneIDMap.GetNameFromID(id.To()),
+			typeName,
+			strconv.FormatFloat(probability, 'f', -1, 64),
+		))
+	}
+	slices.Sort(lines)
+	header := make([]string, 0, len(typeNames))
+	for typeName, regulatory := range typeNames {
+		if regulatory {
+			header = append(header, fmt.Sprintf("%% %s regulatory", typeName))
+		} else {
+			header = append(header, fmt.Sprintf("%% %s non-regulatory", typeName))
+		}
+	}
+	slices.Sort(header)
+	return append(header, lines...)
+}
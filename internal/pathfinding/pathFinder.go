@@ -11,9 +11,10 @@ import (
 // pathFinder is a struct that contains the methods to perform path finding
 type pathFinder struct {
 	*slog.Logger
-	expander       graph.PathExpander
-	pathDefinition graph.PathDefinition
-	sldCutoff      float64
+	expander         graph.PathExpander
+	backwardExpander graph.PathExpander // only set for bidirectional search
+	pathDefinition   graph.PathDefinition
+	sldCutoff        float64
 }
 
 // newPathFinder is a constructor for pathFinder
@@ -124,9 +125,9 @@ func (search pathFinder) findNBestPathsQTL(
 	// TODO: only compute this once for the entire map (for each condition) and pass it to this method
 	maxToScore := maxMutationScore(mutatedGenesMap, filteredConditions, excludedConditions)
 
-	// compute the bound probability for the current path
+	// compute the bound probability for the current path, its probability already includes the from score
 	boundProbability := func(path *graph.Path) float64 {
-		return path.Probability() * fromScore * maxToScore
+		return path.Probability() * maxToScore
 	}
 
 	// BFS with BB
@@ -256,6 +257,16 @@ func (search pathFinder) expandPath(
 	current *graph.Path,
 	cutoff float64,
 	boundProbability func(*graph.Path) float64,
+) []*graph.Path {
+	return search.filterExpansions(current, func(newPath *graph.Path) bool {
+		return boundProbability(newPath) > cutoff
+	})
+}
+
+// filterExpansions expands the current path and keeps the expansions that satisfy keep
+func (search pathFinder) filterExpansions(
+	current *graph.Path,
+	keep func(*graph.Path) bool,
 ) []*graph.Path {
 	expansions, err := search.expander.Expand(current)
 	if err != nil {
@@ -264,8 +275,7 @@ func (search pathFinder) expandPath(
 	}
 	filtered := make([]*graph.Path, 0, len(expansions))
 	for _, newPath := range expansions {
-		score := boundProbability(newPath)
-		if score > cutoff {
+		if keep(newPath) {
 			filtered = append(filtered, newPath)
 		}
 	}
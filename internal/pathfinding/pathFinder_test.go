@@ -0,0 +1,72 @@
+package pathfinding
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// smallNetwork creates a network with the given interactions and probabilities
+func smallNetwork(probabilities map[[2]types.GeneID]float64) *graph.Network {
+	store := types.NewInteractionStore()
+	store.AddInteractionType("pp", false)
+	probabilityMap := types.NewProbabilityMap()
+	for genes, probability := range probabilities {
+		interaction := types.FromToTypeToID(genes[0], genes[1], store.GetInteractionTypeID("pp"))
+		store.AddInteraction(interaction)
+		probabilityMap.SetProbability(interaction, probability)
+	}
+	return graph.NewNetwork(store, probabilityMap, nil, nil)
+}
+
+// TestFindNBestPathsQTLScoredStart tests that the QTL search finds the best scored paths when the start gene scores below 1.
+// The bound used to count the score of the start gene twice, and the results were ordered without the scores of the
+// start and end genes, both of which discarded better paths.
+func TestFindNBestPathsQTLScoredStart(t *testing.T) {
+	include := types.ConditionSet{"end": {}}
+	exclude := types.ConditionSet{"start": {}}
+	testCases := []struct {
+		name          string
+		n             int
+		probabilities map[[2]types.GeneID]float64
+		toScores      map[types.GeneID]float64
+		expected      []string
+	}{
+		{
+			// 1->3->4 scores 0.4*0.5*1, but was pruned by the bound 0.4*0.5*0.5*1 after finding 1->2 with 0.5*0.5*0.5
+			name:          "bound",
+			n:             1,
+			probabilities: map[[2]types.GeneID]float64{{1, 2}: 0.5, {1, 3}: 0.4, {3, 4}: 1},
+			toScores:      map[types.GeneID]float64{2: 0.5, 4: 1},
+			expected:      []string{"0.200000000000"},
+		},
+		{
+			// 1->2 scores 0.9*0.5*0.1 and is the worst result, but was kept because its unscored probability is the highest
+			name:          "priority",
+			n:             2,
+			probabilities: map[[2]types.GeneID]float64{{1, 2}: 0.9, {1, 3}: 0.5, {1, 4}: 0.3},
+			toScores:      map[types.GeneID]float64{2: 0.1, 3: 1, 4: 1},
+			expected:      []string{"0.150000000000", "0.250000000000"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			network := smallNetwork(testCase.probabilities)
+			mutated := make(types.GeneConditionMap[float64])
+			mutated.Add(1, "start", 0.5)
+			endGenes := make(types.GeneSet)
+			for gene, toScore := range testCase.toScores {
+				mutated.Add(gene, "end", toScore)
+				endGenes[gene] = struct{}{}
+			}
+			search := newPathFinder(slog.Default(), graph.NewDownstreamExpander(network), graph.SimplePathDefinition, 0)
+			got, _ := search.findNBestPathsQTL(3, testCase.n, 1, "start", mutated, include, exclude, endGenes, true)
+			if !slices.Equal(pathProbabilities(got), testCase.expected) {
+				t.Errorf("expected probabilities %v, got %v", testCase.expected, pathProbabilities(got))
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
 	"github.com/MarchalLab/gonetic/internal/common/types"
 	"github.com/MarchalLab/gonetic/internal/graph"
 )
@@ -44,7 +45,7 @@ func newRunner(
 		excludedStrains:    excludedConditions,
 		MutatedGeneWeights: mutatedGeneWeights,
 		search:             search,
-		outputFile:         outputFile,
+		outputFile:         fileio.CompressedFileName(outputFile, args.PathCompression),
 		pathLength:         pathLength,
 		nBest:              nBest,
 		condition:          condition,
@@ -93,6 +94,19 @@ func (gpfr runner) findPaths(qtl bool, startIsMutated bool) {
 		)
 	}()
 
+	// the backward halves of a bidirectional search are shared by all start genes
+	var backward backwardIndex
+	if qtl && gpfr.search.isBidirectional() {
+		backward = gpfr.search.buildBackwardIndex(
+			gpfr.pathLength,
+			gpfr.endGenes,
+			gpfr.MutatedGeneWeights,
+			gpfr.strains,
+			gpfr.excludedStrains,
+			gpfr.maxFromScore(startIsMutated),
+		)
+	}
+
 	// loop over all start genes
 	for fromGene := range gpfr.startGenes {
 		gpfr.Sem.Acquire()
@@ -103,7 +117,20 @@ func (gpfr runner) findPaths(qtl bool, startIsMutated bool) {
 			}()
 			var result []*graph.Path
 			var tmp int
-			if qtl {
+			if qtl && gpfr.search.isBidirectional() {
+				result, tmp = gpfr.search.findNBestPathsQTLBidirectional(
+					gpfr.pathLength,
+					gpfr.nBest,
+					from,
+					gpfr.condition,
+					gpfr.MutatedGeneWeights,
+					gpfr.strains,
+					gpfr.excludedStrains,
+					gpfr.endGenes,
+					startIsMutated,
+					backward,
+				)
+			} else if qtl {
 				result, tmp = gpfr.search.findNBestPathsQTL(
 					gpfr.pathLength,
 					gpfr.nBest,
@@ -129,6 +156,20 @@ func (gpfr runner) findPaths(qtl bool, startIsMutated bool) {
 	}
 }
 
+// maxFromScore is an upper bound on the score of the start genes, used to prune the backward halves of a bidirectional search
+func (gpfr runner) maxFromScore(startIsMutated bool) float64 {
+	if !startIsMutated {
+		return 1
+	}
+	maxScore := 0.0
+	for gene := range gpfr.startGenes {
+		if score, ok := gpfr.MutatedGeneWeights.Get(gene, gpfr.condition); ok {
+			maxScore = max(maxScore, score)
+		}
+	}
+	return maxScore
+}
+
 func (gpfr runner) writePathsToFile(
 	result []*graph.Path,
 	from types.GeneID,
@@ -141,7 +182,7 @@ func (gpfr runner) writePathsToFile(
 		}
 		content = append(content, "")
 		outputFileMutex.Lock()
-		if err := gpfr.AppendLinesToFile(gpfr.outputFile, content); err != nil {
+		if err := gpfr.AppendLinesToCompressedFile(gpfr.outputFile, gpfr.PathCompression, content); err != nil {
 			gpfr.Error("Error writing to output file", "error", err)
 			panic("Cannot write paths to file")
 		}
@@ -0,0 +1,208 @@
+package pathfinding
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+// conditionQuery holds the part of a QTL search that is specific to a single run,
+// such that the search tree from a start gene can be shared by all runs in which the start gene is mutated
+type conditionQuery struct {
+	n                int
+	mutated          types.GeneConditionMap[float64]
+	fromScore        float64
+	maxToScore       float64
+	include, exclude types.ConditionSet
+	endGenes         types.GeneSet
+	cutoff           float64
+	results          *PriorityQueue[*graph.Path]
+	closed           bool
+}
+
+// newConditionQuery is a constructor for conditionQuery
+func newConditionQuery(
+	n int,
+	fromScore, sldCutoff float64,
+	mutatedGenesMap types.GeneConditionMap[float64],
+	include, exclude types.ConditionSet,
+	endGenes types.GeneSet,
+) *conditionQuery {
+	return &conditionQuery{
+		n:          n,
+		mutated:    mutatedGenesMap,
+		fromScore:  fromScore,
+		maxToScore: maxMutationScore(mutatedGenesMap, include, exclude),
+		include:    include,
+		exclude:    exclude,
+		endGenes:   endGenes,
+		cutoff:     sldCutoff,
+		results:    NewReversePriorityQueue[*graph.Path](),
+	}
+}
+
+// scoredProbability is the probability the path would have when the search had started from the scored start gene
+func (query *conditionQuery) scoredProbability(path *graph.Path) float64 {
+	return path.Probability() * query.fromScore
+}
+
+// boundProbability is an upper bound on the probability of any path of the query that extends the given path
+func (query *conditionQuery) boundProbability(path *graph.Path) float64 {
+	return query.scoredProbability(path) * query.maxToScore
+}
+
+// accepts returns whether the path can still improve the results of the query
+func (query *conditionQuery) accepts(path *graph.Path) bool {
+	return query.boundProbability(path) > query.cutoff && query.scoredProbability(path) > query.cutoff
+}
+
+// findNBestPathsQTLShared finds the N best paths in the QTL case for several queries starting from the same gene.
+// The best-first search with branch and bound is performed once on the unscored paths, a path is expanded as long as
+// it can improve the results of any query, and the end genes are scored for each query separately.
+// The results are the same as those of findNBestPathsQTL for each query, up to paths with equal probabilities.
+func (search pathFinder) findNBestPathsQTLShared(
+	pathlength int,
+	from types.GeneID,
+	queries []*conditionQuery,
+) ([][]*graph.Path, int) {
+	toVisit := NewPriorityQueue[*graph.Path]()
+	toVisit.Push(graph.RootPath(from, 1))
+	maxToVisitSize := toVisit.Len()
+	for !toVisit.Empty() {
+		maxToVisitSize = max(maxToVisitSize, toVisit.Len())
+		current := toVisit.Pop()
+		// branch and bound, the bounds only decrease as the search goes on, so a closed query stays closed
+		open := false
+		for _, query := range queries {
+			if !query.closed && query.results.Len() >= query.n && query.results.Top().Probability() > query.boundProbability(current) {
+				query.closed = true
+			}
+			open = open || !query.closed
+		}
+		if !open {
+			break // no better path is possible for any query
+		}
+		// If a valid path was found, the scores of the end genes need to be calculated for each query
+		if from != current.EndGene && search.pathDefinition(*current) {
+			for _, query := range queries {
+				_, isEndGene := query.endGenes[current.EndGene]
+				_, isMutatedGene := query.mutated[current.EndGene]
+				if !isEndGene || !isMutatedGene || query.closed {
+					continue
+				}
+				query.cutoff = search.addConditionallyScoredPaths(
+					current,
+					from,
+					query.fromScore,
+					query.n,
+					query.cutoff,
+					query.mutated,
+					query.include,
+					query.exclude,
+					query.results,
+				)
+			}
+		}
+		// construct next paths from current path
+		if current.Length < pathlength {
+			children := search.filterExpansions(current, func(child *graph.Path) bool {
+				for _, query := range queries {
+					if !query.closed && query.accepts(child) {
+						return true
+					}
+				}
+				return false
+			})
+			for _, child := range children {
+				toVisit.Push(child)
+			}
+		}
+	}
+	results := make([][]*graph.Path, len(queries))
+	for i, query := range queries {
+		results[i] = query.results.PopToReverseSlice()
+	}
+	return results, maxToVisitSize
+}
+
+// findPathsShared performs the QTL path finding for several runs at once.
+// The runs must share their search and path length, typically they are the runs of the different conditions of a cohort.
+// The search tree from every start gene is computed once, and filtered for each run in which the start gene is mutated.
+func findPathsShared(runs []runner) {
+	if len(runs) == 0 {
+		return
+	}
+	args := runs[0].Common
+	search := runs[0].search
+	pathLength := runs[0].pathLength
+	startTime := time.Now()
+	// set up output files
+	var outputFileMutex sync.Mutex
+	// the runs in which each start gene is mutated
+	startGenes := make(map[types.GeneID][]int)
+	for i, run := range runs {
+		for gene := range run.startGenes {
+			startGenes[gene] = append(startGenes[gene], i)
+		}
+	}
+	// wait group to sync go routines
+	wg := &sync.WaitGroup{}
+	wg.Add(len(startGenes))
+
+	// channel to collect maxToVisitSize values
+	tmpChannel := make(chan int, len(startGenes))
+
+	// log at the end
+	go func() {
+		wg.Wait()
+		close(tmpChannel)
+		maxToVisitSize := 0
+		for tmp := range tmpChannel {
+			maxToVisitSize = max(maxToVisitSize, tmp)
+		}
+		args.Info(
+			"Finished shared pathfinding",
+			"runs", len(runs),
+			"startGenes", len(startGenes),
+			"runTime", time.Since(startTime).Milliseconds(),
+			"maxToVisitSize", maxToVisitSize,
+		)
+	}()
+
+	// loop over all start genes
+	for fromGene, runIndices := range startGenes {
+		args.Sem.Acquire()
+		go func(from types.GeneID, runIndices []int) {
+			defer func() {
+				args.Sem.Release()
+				wg.Done()
+			}()
+			queryRuns := make([]runner, 0, len(runIndices))
+			queries := make([]*conditionQuery, 0, len(runIndices))
+			for _, i := range runIndices {
+				run := runs[i]
+				fromScore, ok := run.MutatedGeneWeights.Get(from, run.condition)
+				if !ok {
+					continue
+				}
+				queryRuns = append(queryRuns, run)
+				queries = append(queries, newConditionQuery(
+					run.nBest,
+					fromScore,
+					search.sldCutoff,
+					run.MutatedGeneWeights,
+					run.strains,
+					run.excludedStrains,
+					run.endGenes,
+				))
+			}
+			results, tmp := search.findNBestPathsQTLShared(pathLength, from, queries)
+			tmpChannel <- tmp
+			for i, run := range queryRuns {
+				run.writePathsToFile(results[i], from, &outputFileMutex)
+			}
+		}(fromGene, runIndices)
+	}
+}
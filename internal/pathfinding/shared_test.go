@@ -0,0 +1,82 @@
+package pathfinding
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+)
+
+func pathProbabilities(paths []*graph.Path) []string {
+	probabilities := make([]string, 0, len(paths))
+	for _, path := range paths {
+		probabilities = append(probabilities, fmt.Sprintf("%.12f", path.Probability()))
+	}
+	slices.Sort(probabilities)
+	return probabilities
+}
+
+// TestSharedSearch tests that sharing the search tree between conditions finds the same paths as a search per condition
+func TestSharedSearch(t *testing.T) {
+	args := commonArgs("testresult/shared")
+	args.TopologyWeightingAddition = "bayes"
+	args.Init()
+	network := makeNetwork(args, 0)
+
+	// every gene is mutated in a few of the conditions, with different scores
+	conditions := types.ConditionSet{"c0": {}, "c1": {}, "c2": {}, "c3": {}}
+	mutated := make(types.GeneConditionMap[float64])
+	for gene := range network.Genes() {
+		for i := range 4 {
+			if (int(gene)+i)%3 != 0 {
+				mutated.Add(gene, types.Condition(fmt.Sprintf("c%d", i)), 0.3+float64((int(gene)*(i+1))%7)/10)
+			}
+		}
+	}
+	endGenesFor := func(condition types.Condition) types.GeneSet {
+		endGenes := make(types.GeneSet)
+		for gene := range mutated {
+			for other := range mutated[gene] {
+				if other != condition {
+					endGenes[gene] = struct{}{}
+				}
+			}
+		}
+		return endGenes
+	}
+
+	for _, sldCutoff := range []float64{0, 0.001} {
+		for _, n := range []int{3, 10000} {
+			t.Run(fmt.Sprintf("cutoff %v n %d", sldCutoff, n), func(t *testing.T) {
+				search := newPathFinder(args.Logger, graph.NewDownstreamExpander(network), graph.SimplePathDefinition, sldCutoff)
+				for from := range network.Genes() {
+					queryConditions := make([]types.Condition, 0)
+					queries := make([]*conditionQuery, 0)
+					for condition := range conditions {
+						fromScore, ok := mutated.Get(from, condition)
+						if !ok {
+							continue
+						}
+						queryConditions = append(queryConditions, condition)
+						queries = append(queries, newConditionQuery(
+							n, fromScore, sldCutoff, mutated, conditions, types.ConditionSet{condition: {}}, endGenesFor(condition),
+						))
+					}
+					got, _ := search.findNBestPathsQTLShared(3, from, queries)
+					for i, condition := range queryConditions {
+						expected, _ := search.findNBestPathsQTL(3, n, from, condition, mutated, conditions, types.ConditionSet{condition: {}}, endGenesFor(condition), true)
+						// paths with equal probabilities may be exchanged when not all paths are requested
+						if !slices.Equal(pathProbabilities(expected), pathProbabilities(got[i])) {
+							t.Errorf("from %d in %s: expected probabilities %v, got %v", from, condition, pathProbabilities(expected), pathProbabilities(got[i]))
+						}
+						if n == 10000 && !slices.Equal(pathSignatures(expected), pathSignatures(got[i])) {
+							t.Errorf("from %d in %s: expected paths %v, got %v", from, condition, pathSignatures(expected), pathSignatures(got[i]))
+						}
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package pathweight
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// maxCopyNumberAmplitude is the thresholded copy number of a high-level amplification or a deep deletion
+const maxCopyNumberAmplitude = 2
+
+// CNARelevanceScorePerGene calculates the weight of each altered gene in each condition from the amplitude and the
+// focality of the copy-number alteration. The entries contain the gene name, copy number, condition and optionally the
+// focality, separated by tabs. The amplitude is the absolute thresholded copy number scaled to [0, 1], so low-level
+// gains and shallow deletions weigh half of high-level amplifications and deep deletions. The focality is the fraction
+// in [0, 1] that the alteration is focal rather than broad, and alterations without a focality are considered focal.
+// If a gene has multiple alterations in one condition, the alteration with the highest weight is retained.
+func CNARelevanceScorePerGene(logger *slog.Logger, gim *types.GeneIDMap, cnaData map[string]struct{}) types.GeneConditionMap[float64] {
+	weightsPerGene := make(types.GeneConditionMap[float64])
+	for alteration := range cnaData {
+		split := strings.Split(alteration, "\t")
+		if len(split) < 3 {
+			continue
+		}
+		copyNumber, err := strconv.ParseFloat(split[1], 64)
+		if err != nil {
+			logger.Warn("Skipping copy-number alteration without a numeric copy number", "alteration", alteration)
+			continue
+		}
+		focality := 1.0
+		if len(split) > 3 && split[3] != "" && split[3] != "NA" {
+			focality, err = strconv.ParseFloat(split[3], 64)
+			if err != nil {
+				logger.Warn("Skipping copy-number alteration without a numeric focality", "alteration", alteration)
+				continue
+			}
+			focality = math.Min(math.Max(focality, 0), 1)
+		}
+		amplitude := math.Min(math.Abs(copyNumber), maxCopyNumberAmplitude) / maxCopyNumberAmplitude
+		weight := amplitude * focality
+		gene := gim.GetIDFromName(types.GeneName(split[0]))
+		condition := types.Condition(split[2])
+		if current, ok := weightsPerGene.Get(gene, condition); !ok || current < weight {
+			weightsPerGene.Add(gene, condition, weight)
+		}
+	}
+	return weightsPerGene
+}
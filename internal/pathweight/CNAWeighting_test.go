@@ -0,0 +1,41 @@
+package pathweight
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func TestCNARelevanceScorePerGene(t *testing.T) {
+	gim := types.NewGeneIDMap()
+	for _, gene := range []string{"MYC", "PTEN", "ERBB2"} {
+		gim.SetName(gene)
+	}
+	cnaData := map[string]struct{}{
+		"MYC\t2\tcondition1":        {},
+		"MYC\t1\tcondition2\t0.5":   {},
+		"PTEN\t-1\tcondition1":      {},
+		"PTEN\t-2\tcondition1\t0.4": {},
+		"ERBB2\t4\tcondition1\tNA":  {},
+	}
+	weights := CNARelevanceScorePerGene(slog.Default(), gim, cnaData)
+	tests := []struct {
+		gene      string
+		condition types.Condition
+		expected  float64
+	}{
+		{"MYC", "condition1", 1},
+		{"MYC", "condition2", 0.25},
+		// the alteration with the highest weight is retained
+		{"PTEN", "condition1", 0.5},
+		// amplifications beyond the thresholded copy numbers have the maximal amplitude
+		{"ERBB2", "condition1", 1},
+	}
+	for _, test := range tests {
+		weight, ok := weights.Get(gim.GetIDFromName(types.GeneName(test.gene)), test.condition)
+		if !ok || weight != test.expected {
+			t.Errorf("expected weight %f for %s in %s, got %f", test.expected, test.gene, test.condition, weight)
+		}
+	}
+}
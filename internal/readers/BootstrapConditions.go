@@ -0,0 +1,70 @@
+package readers
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// BootstrapConditions resamples the conditions of the input data with replacement. The same conditions are drawn for
+// all given files, such that e.g. the mutations and the differential expression of a condition stay together. A
+// condition that is drawn more than once is repeated under a new name, so that every draw is a distinct condition.
+// Files without a condition column, e.g. input data that is not given, are returned unchanged.
+func BootstrapConditions(fileData ...FileData) []FileData {
+	// the distinct conditions over all files, in order of appearance
+	conditions := make([]string, 0)
+	for _, data := range fileData {
+		conditionColumn, ok := data.Headers["condition"]
+		if !ok {
+			continue
+		}
+		for _, entry := range data.Entries {
+			if !slices.Contains(conditions, entry[conditionColumn]) {
+				conditions = append(conditions, entry[conditionColumn])
+			}
+		}
+	}
+	// draw the conditions with replacement
+	draws := make([][2]string, 0, len(conditions))
+	drawCount := make(map[string]int, len(conditions))
+	for range conditions {
+		condition := conditions[rand.Intn(len(conditions))]
+		name := condition
+		if drawCount[condition] > 0 {
+			name = fmt.Sprintf("%s_bootstrap%d", condition, drawCount[condition])
+		}
+		drawCount[condition]++
+		draws = append(draws, [2]string{condition, name})
+	}
+	return resampleConditions(draws, fileData)
+}
+
+// resampleConditions returns the entries of each drawn condition under the name of the draw
+func resampleConditions(draws [][2]string, fileData []FileData) []FileData {
+	resampled := make([]FileData, 0, len(fileData))
+	for _, data := range fileData {
+		conditionColumn, ok := data.Headers["condition"]
+		if !ok {
+			resampled = append(resampled, data)
+			continue
+		}
+		entriesPerCondition := make(map[string][][]string)
+		for _, entry := range data.Entries {
+			entriesPerCondition[entry[conditionColumn]] = append(entriesPerCondition[entry[conditionColumn]], entry)
+		}
+		drawn := FileData{
+			ID:      data.ID,
+			Headers: data.Headers,
+			Entries: make([][]string, 0, len(data.Entries)),
+		}
+		for _, draw := range draws {
+			for _, entry := range entriesPerCondition[draw[0]] {
+				drawnEntry := slices.Clone(entry)
+				drawnEntry[conditionColumn] = draw[1]
+				drawn.Entries = append(drawn.Entries, drawnEntry)
+			}
+		}
+		resampled = append(resampled, drawn)
+	}
+	return resampled
+}
@@ -0,0 +1,65 @@
+package readers
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBootstrapConditions(t *testing.T) {
+	mutations := FileData{
+		ID:      "mutation",
+		Headers: map[string]int{"gene name": 0, "condition": 1},
+		Entries: [][]string{
+			{"a", "s1"},
+			{"b", "s1"},
+			{"c", "s2"},
+			{"d", "s3"},
+		},
+	}
+	expression := FileData{
+		ID:      "differential expression",
+		Headers: map[string]int{"condition": 0, "gene name": 1},
+		Entries: [][]string{
+			{"s1", "x"},
+			{"s2", "y"},
+			{"s3", "z"},
+		},
+	}
+	original := map[string]string{"s1": "x", "s2": "y", "s3": "z"}
+
+	for range 10 {
+		resampled := BootstrapConditions(mutations, expression, FileData{})
+		if len(resampled) != 3 {
+			t.Fatalf("expected 3 files, got %d", len(resampled))
+		}
+		// every condition is drawn once, and repeated draws get a new name
+		conditions := make([]string, 0)
+		for _, entry := range resampled[1].Entries {
+			if slices.Contains(conditions, entry[0]) {
+				t.Errorf("expected distinct conditions, got %s twice", entry[0])
+			}
+			conditions = append(conditions, entry[0])
+			// the expression of a condition moves with the condition
+			if entry[1] != original[strings.Split(entry[0], "_bootstrap")[0]] {
+				t.Errorf("expected condition %s to keep its expression, got %s", entry[0], entry[1])
+			}
+		}
+		if len(conditions) != 3 {
+			t.Errorf("expected 3 conditions, got %v", conditions)
+		}
+		// the mutations are drawn for the same conditions
+		for _, entry := range resampled[0].Entries {
+			if !slices.Contains(conditions, entry[1]) {
+				t.Errorf("expected mutation %v to belong to a drawn condition %v", entry, conditions)
+			}
+		}
+		if resampled[2].Headers != nil {
+			t.Errorf("expected input data without conditions to be unchanged")
+		}
+	}
+	// the original data is left untouched
+	if mutations.Entries[0][1] != "s1" || len(mutations.Entries) != 4 {
+		t.Errorf("expected the original mutations to be unchanged, got %v", mutations.Entries)
+	}
+}
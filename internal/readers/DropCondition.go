@@ -0,0 +1,26 @@
+package readers
+
+// DropCondition removes the entries of the condition from the input data. Files without a condition column, e.g. input
+// data that is not given, are returned unchanged.
+func DropCondition(condition string, fileData ...FileData) []FileData {
+	remaining := make([]FileData, 0, len(fileData))
+	for _, data := range fileData {
+		conditionColumn, ok := data.Headers["condition"]
+		if !ok {
+			remaining = append(remaining, data)
+			continue
+		}
+		kept := FileData{
+			ID:      data.ID,
+			Headers: data.Headers,
+			Entries: make([][]string, 0, len(data.Entries)),
+		}
+		for _, entry := range data.Entries {
+			if entry[conditionColumn] != condition {
+				kept.Entries = append(kept.Entries, entry)
+			}
+		}
+		remaining = append(remaining, kept)
+	}
+	return remaining
+}
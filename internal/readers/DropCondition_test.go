@@ -0,0 +1,33 @@
+package readers
+
+import (
+	"testing"
+)
+
+func TestDropCondition(t *testing.T) {
+	mutations := FileData{
+		ID:      "mutation",
+		Headers: map[string]int{"gene name": 0, "condition": 1},
+		Entries: [][]string{
+			{"a", "s1"},
+			{"b", "s2"},
+			{"c", "s1"},
+		},
+	}
+	remaining := DropCondition("s1", mutations, FileData{})
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(remaining))
+	}
+	if len(remaining[0].Entries) != 1 || remaining[0].Entries[0][0] != "b" {
+		t.Errorf("expected only the mutation of s2 to remain, got %v", remaining[0].Entries)
+	}
+	if _, ok := LoadConditionData(remaining[0], "condition")["s1"]; ok {
+		t.Errorf("expected condition s1 to be dropped")
+	}
+	if remaining[1].Headers != nil {
+		t.Errorf("expected input data without conditions to be unchanged")
+	}
+	if len(mutations.Entries) != 3 {
+		t.Errorf("expected the original mutations to be unchanged, got %v", mutations.Entries)
+	}
+}
@@ -0,0 +1,395 @@
+package readers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+)
+
+// mutation file formats
+const (
+	MutationFormatAuto = "auto"
+	MutationFormatCSV  = "csv"
+	MutationFormatVCF  = "vcf"
+	MutationFormatMAF  = "maf"
+)
+
+// MutationFormats are the valid values of --mutation-format
+var MutationFormats = []string{
+	MutationFormatAuto,
+	MutationFormatCSV,
+	MutationFormatVCF,
+	MutationFormatMAF,
+}
+
+// the variant calls are long lines, e.g. with many samples or annotations
+const maxVariantLineLength = 64 * 1024 * 1024
+
+// variantCall is a mutated gene of a sample, as it is read from a VCF or MAF file
+type variantCall struct {
+	gene           string
+	sample         string
+	classification string
+	// the variant allele frequency, negative when it is unknown
+	vaf float64
+}
+
+// VariantFilter keeps the variant calls by their variant classification, e.g. the Variant_Classification of a MAF file
+// or the consequence of the ANN or CSQ annotation of a VCF file. The classifications are matched case-insensitively.
+type VariantFilter struct {
+	keep    []string
+	exclude []string
+}
+
+// NewVariantFilter creates a filter that keeps the given classifications, or all classifications when none are given,
+// and excludes the excluded classifications
+func NewVariantFilter(keep, exclude []string) VariantFilter {
+	lower := func(values []string) []string {
+		result := make([]string, 0, len(values))
+		for _, value := range values {
+			result = append(result, strings.ToLower(value))
+		}
+		return result
+	}
+	return VariantFilter{keep: lower(keep), exclude: lower(exclude)}
+}
+
+// Accepts returns whether a variant with the classification is kept. The consequences of a VCF annotation are joined
+// by &, the variant is kept when one of them is kept and none of them is excluded.
+func (filter VariantFilter) Accepts(classification string) bool {
+	consequences := strings.Split(strings.ToLower(classification), "&")
+	for _, consequence := range consequences {
+		if slices.Contains(filter.exclude, consequence) {
+			return false
+		}
+	}
+	if len(filter.keep) == 0 {
+		return true
+	}
+	for _, consequence := range consequences {
+		if slices.Contains(filter.keep, consequence) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadMutationFile reads a mutation file in the given format into the same data as a mutation CSV file. VCF and MAF
+// files can be gzip compressed, and their variants are filtered on their classification.
+func ReadMutationFile(logger *slog.Logger, fileName, format string, filter VariantFilter) FileData {
+	if fileName == "" {
+		return FileData{}
+	}
+	if format == "" || format == MutationFormatAuto {
+		format = DetectMutationFormat(fileName)
+	}
+	if format == MutationFormatCSV {
+		return ReadInputDataHeadersMutationFile(logger, fileName)
+	}
+	file, err := fileio.OpenDecompressedFile(fileName)
+	if err != nil {
+		logger.Error("Failed to open mutation file", "error", err, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	defer file.Close()
+	var calls []variantCall
+	switch format {
+	case MutationFormatVCF:
+		calls, err = readVCF(file)
+	case MutationFormatMAF:
+		calls, err = readMAF(file)
+	default:
+		err = fmt.Errorf("unknown mutation format %q, expected one of %v", format, MutationFormats)
+	}
+	if err != nil {
+		logger.Error("Failed to read mutation file", "error", err, "format", format, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	return variantFileData(logger, fileName, calls, filter)
+}
+
+// DetectMutationFormat detects the format of a mutation file from its extension, ignoring compression extensions,
+// and its first line
+func DetectMutationFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileio.TrimCompressionExtension(fileName))) {
+	case ".vcf":
+		return MutationFormatVCF
+	case ".maf":
+		return MutationFormatMAF
+	}
+	file, err := fileio.OpenDecompressedFile(fileName)
+	if err != nil {
+		return MutationFormatCSV
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxVariantLineLength)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "##fileformat=VCF"):
+			return MutationFormatVCF
+		case strings.HasPrefix(line, "Hugo_Symbol"):
+			return MutationFormatMAF
+		case len(line) == 0, strings.HasPrefix(line, "#version"):
+			// MAF files can start with version comments
+			continue
+		}
+		return MutationFormatCSV
+	}
+	return MutationFormatCSV
+}
+
+// variantFileData converts the accepted variant calls into the columns of a mutation CSV file. The freq increase
+// column holds the variant allele frequencies, it is only present when they are known for every accepted call.
+func variantFileData(logger *slog.Logger, fileName string, calls []variantCall, filter VariantFilter) FileData {
+	accepted := make([]variantCall, 0, len(calls))
+	withVAF := true
+	for _, call := range calls {
+		if !filter.Accepts(call.classification) {
+			continue
+		}
+		accepted = append(accepted, call)
+		withVAF = withVAF && call.vaf >= 0
+	}
+	data := FileData{
+		ID:      "mutation",
+		Headers: map[string]int{"gene name": 0, "condition": 1},
+		Entries: make([][]string, 0, len(accepted)),
+	}
+	if withVAF && len(accepted) > 0 {
+		data.Headers["freq increase"] = 2
+	} else if len(accepted) > 0 {
+		logger.Warn("Variant allele frequencies are missing, the mutation data has no freq increase", "fileName", fileName)
+	}
+	for _, call := range accepted {
+		entry := []string{call.gene, call.sample}
+		if withVAF {
+			entry = append(entry, strconv.FormatFloat(call.vaf, 'f', -1, 64))
+		}
+		data.Entries = append(data.Entries, entry)
+	}
+	logger.Info("Finished reading variant file", "fileName", fileName, "variants", len(calls), "entries", len(data.Entries))
+	return data
+}
+
+// readMAF reads the variant calls of a mutation annotation format file, the allele frequency is t_alt_count divided by
+// t_depth, or by the sum of t_ref_count and t_alt_count
+func readMAF(reader io.Reader) ([]variantCall, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxVariantLineLength)
+	var columns map[string]int
+	calls := make([]variantCall, 0)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(strings.TrimSpace(line)) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens := strings.Split(line, "\t")
+		if columns == nil {
+			columns = make(map[string]int, len(tokens))
+			for i, token := range tokens {
+				columns[token] = i
+			}
+			for _, required := range []string{"Hugo_Symbol", "Tumor_Sample_Barcode"} {
+				if _, ok := columns[required]; !ok {
+					return nil, fmt.Errorf("MAF header misses the column %s", required)
+				}
+			}
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(tokens) {
+				return tokens[i]
+			}
+			return ""
+		}
+		gene := field("Hugo_Symbol")
+		if gene == "" || gene == "Unknown" {
+			continue
+		}
+		vaf := -1.0
+		alt, altErr := strconv.ParseFloat(field("t_alt_count"), 64)
+		depth, depthErr := strconv.ParseFloat(field("t_depth"), 64)
+		if ref, refErr := strconv.ParseFloat(field("t_ref_count"), 64); depthErr != nil && refErr == nil {
+			depth, depthErr = ref+alt, nil
+		}
+		if altErr == nil && depthErr == nil && depth > 0 {
+			vaf = alt / depth
+		}
+		calls = append(calls, variantCall{
+			gene:           gene,
+			sample:         field("Tumor_Sample_Barcode"),
+			classification: field("Variant_Classification"),
+			vaf:            vaf,
+		})
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("MAF file has no header")
+	}
+	return calls, scanner.Err()
+}
+
+// vcfAnnotation locates the gene and the consequence in the ANN (SnpEff) or CSQ (VEP) annotation of a VCF file
+type vcfAnnotation struct {
+	key         string
+	gene        int
+	consequence int
+}
+
+// the fields of an ANN annotation are fixed: Allele | Annotation | Annotation_Impact | Gene_Name | ...
+var annAnnotation = vcfAnnotation{key: "ANN", gene: 3, consequence: 1}
+
+// csqAnnotation locates the SYMBOL and Consequence fields in the Format of the CSQ description of a VCF header line
+func csqAnnotation(line string) (vcfAnnotation, bool) {
+	_, format, found := strings.Cut(line, "Format: ")
+	if !found {
+		return vcfAnnotation{}, false
+	}
+	format, _, _ = strings.Cut(format, "\"")
+	annotation := vcfAnnotation{key: "CSQ", gene: -1, consequence: -1}
+	for i, field := range strings.Split(format, "|") {
+		switch strings.TrimSpace(field) {
+		case "SYMBOL":
+			annotation.gene = i
+		case "Consequence":
+			annotation.consequence = i
+		}
+	}
+	return annotation, annotation.gene >= 0
+}
+
+// readVCF reads the variant calls of a VCF file. The gene and the classification are those of the first ANN or CSQ
+// annotation with a gene. A sample carries the variant when its genotype has an alternative allele, and its allele
+// frequency is the sum of the alternative allele depths of AD divided by DP, or by the sum of AD.
+func readVCF(reader io.Reader) ([]variantCall, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxVariantLineLength)
+	annotations := make([]vcfAnnotation, 0, 2)
+	var samples []string
+	calls := make([]variantCall, 0)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, "##INFO=<ID=ANN,"):
+			annotations = append(annotations, annAnnotation)
+			continue
+		case strings.HasPrefix(line, "##INFO=<ID=CSQ,"):
+			if annotation, ok := csqAnnotation(line); ok {
+				annotations = append(annotations, annotation)
+			}
+			continue
+		case strings.HasPrefix(line, "##"), len(strings.TrimSpace(line)) == 0:
+			continue
+		case strings.HasPrefix(line, "#CHROM"):
+			columns := strings.Split(line, "\t")
+			if len(columns) < 10 {
+				return nil, fmt.Errorf("VCF header has no sample columns")
+			}
+			samples = columns[9:]
+			continue
+		}
+		if samples == nil {
+			return nil, fmt.Errorf("VCF file has no #CHROM header line")
+		}
+		if len(annotations) == 0 {
+			return nil, fmt.Errorf("VCF file has no ANN or CSQ annotation")
+		}
+		tokens := strings.Split(line, "\t")
+		if len(tokens) != len(samples)+9 {
+			return nil, fmt.Errorf("VCF line has %d columns, expected %d", len(tokens), len(samples)+9)
+		}
+		gene, classification := vcfGene(tokens[7], annotations)
+		if gene == "" {
+			continue
+		}
+		format := strings.Split(tokens[8], ":")
+		for i, sample := range samples {
+			vaf, carrier := vcfGenotype(format, strings.Split(tokens[9+i], ":"))
+			if !carrier {
+				continue
+			}
+			calls = append(calls, variantCall{
+				gene:           gene,
+				sample:         sample,
+				classification: classification,
+				vaf:            vaf,
+			})
+		}
+	}
+	return calls, scanner.Err()
+}
+
+// vcfGene returns the gene and the consequence of the first annotation with a gene in the INFO column
+func vcfGene(info string, annotations []vcfAnnotation) (string, string) {
+	for _, entry := range strings.Split(info, ";") {
+		key, value, _ := strings.Cut(entry, "=")
+		for _, annotation := range annotations {
+			if key != annotation.key {
+				continue
+			}
+			for _, transcript := range strings.Split(value, ",") {
+				fields := strings.Split(transcript, "|")
+				if annotation.gene >= len(fields) || fields[annotation.gene] == "" {
+					continue
+				}
+				consequence := ""
+				if annotation.consequence >= 0 && annotation.consequence < len(fields) {
+					consequence = fields[annotation.consequence]
+				}
+				return fields[annotation.gene], consequence
+			}
+		}
+	}
+	return "", ""
+}
+
+// vcfGenotype returns the allele frequency of a sample, negative when it is unknown, and whether its genotype carries
+// an alternative allele
+func vcfGenotype(format, values []string) (float64, bool) {
+	field := func(name string) string {
+		if i := slices.Index(format, name); i >= 0 && i < len(values) {
+			return values[i]
+		}
+		return ""
+	}
+	carrier := false
+	for _, allele := range strings.FieldsFunc(field("GT"), func(r rune) bool { return r == '/' || r == '|' }) {
+		if allele != "0" && allele != "." {
+			carrier = true
+		}
+	}
+	if !carrier {
+		return -1, false
+	}
+	depths := strings.Split(field("AD"), ",")
+	if len(depths) < 2 {
+		return -1, true
+	}
+	total, alt := 0.0, 0.0
+	for i, value := range depths {
+		depth, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return -1, true
+		}
+		total += depth
+		if i > 0 {
+			alt += depth
+		}
+	}
+	if dp, err := strconv.ParseFloat(field("DP"), 64); err == nil && dp > 0 {
+		total = dp
+	}
+	if total == 0 {
+		return -1, true
+	}
+	return alt / total, true
+}
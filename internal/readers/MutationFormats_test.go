@@ -0,0 +1,70 @@
+package readers
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+)
+
+// mutationEntries formats the entries of mutation data as sorted gene\tcondition[\tfreq increase] lines
+func mutationEntries(data FileData) []string {
+	lines := make([]string, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		line := entry[data.Headers["gene name"]] + "\t" + entry[data.Headers["condition"]]
+		if index, ok := data.Headers["freq increase"]; ok {
+			line += "\t" + entry[index]
+		}
+		lines = append(lines, line)
+	}
+	slices.Sort(lines)
+	return lines
+}
+
+func TestReadMutationFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		filter   VariantFilter
+		expected []string
+	}{
+		{"vcf", "testdata/mutations.vcf", NewVariantFilter(nil, nil), []string{"FOS\ts1\t0.5", "FOS\ts2\t1", "MYC\ts1\t0.8", "TP53\ts1\t0.25"}},
+		{"vcf excluded", "testdata/mutations.vcf", NewVariantFilter(nil, []string{"synonymous_variant"}), []string{"MYC\ts1\t0.8", "TP53\ts1\t0.25"}},
+		{"vcf kept", "testdata/mutations.vcf", NewVariantFilter([]string{"Splice_Region_Variant"}, nil), []string{"MYC\ts1\t0.8"}},
+		{"vcf csq gzip", "testdata/mutations.csq.vcf.gz", NewVariantFilter(nil, nil), []string{"FOS\ts1", "TP53\ts1"}},
+		{"maf", "testdata/mutations.maf", NewVariantFilter(nil, nil), []string{"FOS\tTCGA-01\t0.5", "MYC\tTCGA-02\t0.25", "TP53\tTCGA-01\t0.25"}},
+		{"maf excluded", "testdata/mutations.maf", NewVariantFilter(nil, []string{"silent"}), []string{"MYC\tTCGA-02\t0.25", "TP53\tTCGA-01\t0.25"}},
+	}
+	for _, test := range tests {
+		data := ReadMutationFile(slog.Default(), test.fileName, MutationFormatAuto, test.filter)
+		if data.ID != "mutation" {
+			t.Errorf("%s: expected mutation data, got %q", test.name, data.ID)
+		}
+		if entries := mutationEntries(data); !slices.Equal(entries, test.expected) {
+			t.Errorf("%s: expected entries %q, got %q", test.name, test.expected, entries)
+		}
+	}
+}
+
+func TestDetectMutationFormat(t *testing.T) {
+	outputDir := "testresult/MutationFormats"
+	fileio.CreateEmptyDir(outputDir)
+	files := map[string]string{
+		MutationFormatVCF: "##fileformat=VCFv4.2\n",
+		MutationFormatMAF: "#version 2.4\nHugo_Symbol\tTumor_Sample_Barcode\n",
+		MutationFormatCSV: "#gene name,condition\nTP53,s1\n",
+	}
+	for format, content := range files {
+		fileName := filepath.Join(outputDir, strings.ToUpper(format)+".txt")
+		if err := os.WriteFile(fileName, []byte(content), 0666); err != nil {
+			t.Fatalf("failed to write mutation file: %v", err)
+		}
+		if detected := DetectMutationFormat(fileName); detected != format {
+			t.Errorf("expected format %s for %s, got %s", format, fileName, detected)
+		}
+	}
+}
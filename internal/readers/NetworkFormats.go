@@ -0,0 +1,377 @@
+package readers
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+)
+
+// network file formats
+const (
+	NetworkFormatAuto    = "auto"
+	NetworkFormatGonetic = "gonetic"
+	NetworkFormatSIF     = "sif"
+	NetworkFormatGraphML = "graphml"
+	NetworkFormatSTRING  = "string"
+	NetworkFormatBioGRID = "biogrid"
+)
+
+// NetworkFormats are the valid values of --network-format
+var NetworkFormats = []string{
+	NetworkFormatAuto,
+	NetworkFormatGonetic,
+	NetworkFormatSIF,
+	NetworkFormatGraphML,
+	NetworkFormatSTRING,
+	NetworkFormatBioGRID,
+}
+
+// sourceInteraction is an interaction as it is read from a network file in a foreign format, before its label is
+// mapped to an interaction type
+type sourceInteraction struct {
+	from, to    string
+	label       string
+	directed    bool
+	probability float64
+}
+
+// interactionTypeMapping maps the interaction label of a foreign network format to an interaction type
+type interactionTypeMapping struct {
+	name       string
+	regulatory bool
+	directed   bool
+}
+
+// InteractionTypeMappings maps the interaction labels of foreign network formats to interaction types
+type InteractionTypeMappings map[string]interactionTypeMapping
+
+// ReadInteractionTypeMappings reads a mapping file with the tab-separated columns: source label, interaction type,
+// regulatory|non-regulatory and directed|undirected. Lines starting with # are comments.
+func ReadInteractionTypeMappings(fileName string) (InteractionTypeMappings, error) {
+	mappings := make(InteractionTypeMappings)
+	if fileName == "" {
+		return mappings, nil
+	}
+	for _, line := range fileio.ReadListFromFile(fileName, true) {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens := strings.Split(line, "\t")
+		if len(tokens) != 4 {
+			return nil, fmt.Errorf("interaction type mapping %q should have four tab-separated columns", line)
+		}
+		if strings.ContainsAny(tokens[1], " \t") {
+			return nil, fmt.Errorf("interaction type %q can not contain whitespace", tokens[1])
+		}
+		mappings[tokens[0]] = interactionTypeMapping{
+			name:       tokens[1],
+			regulatory: tokens[2] == "regulatory",
+			directed:   tokens[3] == "directed",
+		}
+	}
+	return mappings, nil
+}
+
+// DetectNetworkFormat detects the format of a network file from its extension and its first line
+func DetectNetworkFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".sif":
+		return NetworkFormatSIF
+	case ".graphml":
+		return NetworkFormatGraphML
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return NetworkFormatGonetic
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "<?xml"), strings.HasPrefix(line, "<graphml"):
+			return NetworkFormatGraphML
+		case strings.HasPrefix(line, "protein1 protein2"):
+			return NetworkFormatSTRING
+		case strings.HasPrefix(line, "#BioGRID Interaction ID"):
+			return NetworkFormatBioGRID
+		}
+		return NetworkFormatGonetic
+	}
+	return NetworkFormatGonetic
+}
+
+// readSourceInteractions reads the interactions of a network file in a foreign format
+func readSourceInteractions(format string, reader io.Reader) ([]sourceInteraction, error) {
+	switch format {
+	case NetworkFormatSIF:
+		return readSIF(reader)
+	case NetworkFormatGraphML:
+		return readGraphML(reader)
+	case NetworkFormatSTRING:
+		return readSTRING(reader)
+	case NetworkFormatBioGRID:
+		return readBioGRID(reader)
+	default:
+		return nil, fmt.Errorf("unknown network format %s", format)
+	}
+}
+
+// readSIF reads a simple interaction format file, in which each line holds a source gene, an interaction label and
+// one or more target genes. SIF interactions are undirected unless the label is mapped otherwise.
+func readSIF(reader io.Reader) ([]sourceInteraction, error) {
+	interactions := make([]sourceInteraction, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		separator := "\t"
+		if !strings.Contains(line, separator) {
+			separator = " "
+		}
+		tokens := strings.Split(line, separator)
+		if len(tokens) < 3 {
+			// a single gene without interactions
+			continue
+		}
+		for _, to := range tokens[2:] {
+			if len(to) == 0 {
+				continue
+			}
+			interactions = append(interactions, sourceInteraction{
+				from:        tokens[0],
+				to:          to,
+				label:       tokens[1],
+				probability: 1,
+			})
+		}
+	}
+	return interactions, scanner.Err()
+}
+
+// readSTRING reads a STRING protein.links file, in which the combined score of 0 to 1000 is mapped to a probability.
+// STRING interactions are undirected.
+func readSTRING(reader io.Reader) ([]sourceInteraction, error) {
+	interactions := make([]sourceInteraction, 0)
+	scanner := bufio.NewScanner(reader)
+	scoreColumn := -1
+	for scanner.Scan() {
+		tokens := strings.Fields(scanner.Text())
+		if len(tokens) == 0 {
+			continue
+		}
+		if scoreColumn < 0 {
+			for i, token := range tokens {
+				if token == "combined_score" {
+					scoreColumn = i
+				}
+			}
+			if scoreColumn < 2 {
+				return nil, fmt.Errorf("STRING header %v has no combined_score column", tokens)
+			}
+			continue
+		}
+		if len(tokens) <= scoreColumn {
+			return nil, fmt.Errorf("STRING line %v has no combined score", tokens)
+		}
+		score, err := strconv.ParseFloat(tokens[scoreColumn], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse STRING combined score: %w", err)
+		}
+		interactions = append(interactions, sourceInteraction{
+			from:        tokens[0],
+			to:          tokens[1],
+			label:       NetworkFormatSTRING,
+			probability: score / 1000,
+		})
+	}
+	return interactions, scanner.Err()
+}
+
+// readBioGRID reads a BioGRID TAB3 file. The genes are the official symbols of the interactors, and the interactions are
+// labelled with their experimental system. BioGRID interactions are undirected.
+func readBioGRID(reader io.Reader) ([]sourceInteraction, error) {
+	interactions := make([]sourceInteraction, 0)
+	scanner := bufio.NewScanner(reader)
+	columns := make(map[string]int)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		tokens := strings.Split(line, "\t")
+		if len(columns) == 0 {
+			for i, token := range tokens {
+				columns[strings.TrimPrefix(token, "#")] = i
+			}
+			for _, column := range []string{"Official Symbol Interactor A", "Official Symbol Interactor B", "Experimental System"} {
+				if _, ok := columns[column]; !ok {
+					return nil, fmt.Errorf("BioGRID header has no %s column", column)
+				}
+			}
+			continue
+		}
+		if len(tokens) < len(columns) {
+			return nil, fmt.Errorf("BioGRID line has %d columns, expected %d", len(tokens), len(columns))
+		}
+		interactions = append(interactions, sourceInteraction{
+			from:        tokens[columns["Official Symbol Interactor A"]],
+			to:          tokens[columns["Official Symbol Interactor B"]],
+			label:       tokens[columns["Experimental System"]],
+			probability: 1,
+		})
+	}
+	return interactions, scanner.Err()
+}
+
+// graphML is the subset of the GraphML format that describes the genes and their interactions
+type graphML struct {
+	Keys  []graphMLKey `xml:"key"`
+	Graph struct {
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source   string        `xml:"source,attr"`
+	Target   string        `xml:"target,attr"`
+	Directed string        `xml:"directed,attr"`
+	Data     []graphMLData `xml:"data"`
+}
+
+// readGraphML reads a GraphML file. Genes are named by their name or label attribute, or by their id otherwise. The
+// interaction label is read from the interaction or type attribute, and the probability from the probability, weight
+// or score attribute.
+func readGraphML(reader io.Reader) ([]sourceInteraction, error) {
+	var document graphML
+	if err := xml.NewDecoder(reader).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphML: %w", err)
+	}
+	// the attribute names of the data keys
+	attributes := make(map[string]string, len(document.Keys))
+	for _, key := range document.Keys {
+		attributes[key.ID] = strings.ToLower(key.Name)
+	}
+	attribute := func(data []graphMLData, names ...string) (string, bool) {
+		for _, name := range names {
+			for _, d := range data {
+				if attributes[d.Key] == name {
+					return strings.TrimSpace(d.Value), true
+				}
+			}
+		}
+		return "", false
+	}
+	genes := make(map[string]string, len(document.Graph.Nodes))
+	for _, node := range document.Graph.Nodes {
+		genes[node.ID] = node.ID
+		if name, ok := attribute(node.Data, "name", "label"); ok && len(name) > 0 {
+			genes[node.ID] = name
+		}
+	}
+	gene := func(id string) string {
+		if name, ok := genes[id]; ok {
+			return name
+		}
+		return id
+	}
+	interactions := make([]sourceInteraction, 0, len(document.Graph.Edges))
+	for _, edge := range document.Graph.Edges {
+		interaction := sourceInteraction{
+			from:        gene(edge.Source),
+			to:          gene(edge.Target),
+			label:       "unknown",
+			directed:    document.Graph.EdgeDefault == "directed",
+			probability: 1,
+		}
+		if edge.Directed != "" {
+			interaction.directed = edge.Directed == "true"
+		}
+		if label, ok := attribute(edge.Data, "interaction", "type"); ok && len(label) > 0 {
+			interaction.label = label
+		}
+		if value, ok := attribute(edge.Data, "probability", "weight", "score"); ok {
+			probability, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse GraphML edge probability: %w", err)
+			}
+			interaction.probability = probability
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions, nil
+}
+
+// interactionTypeName turns an interaction label into a valid interaction type name, which can not contain whitespace
+func interactionTypeName(label string) string {
+	return strings.Join(strings.Fields(label), "_")
+}
+
+// fromSourceInteractions maps the interactions of a foreign network format onto the interaction types, and adds them
+// to a parsed network
+func (nwr NetworkReader) fromSourceInteractions(interactions []sourceInteraction) *parsedNetwork {
+	parsed := newParsedNetwork()
+	store := arguments.GlobalInteractionStore
+	store.AddInteractionType("unknown", false)
+	for _, interaction := range interactions {
+		mapping, ok := nwr.mappings[interaction.label]
+		if !ok {
+			mapping = interactionTypeMapping{
+				name:     interactionTypeName(interaction.label),
+				directed: interaction.directed,
+			}
+		}
+		if _, ok := parsed.interactionTypes[mapping.name]; !ok {
+			nwr.processHeader(parsedInteractionType{name: mapping.name, isReg: mapping.regulatory}, parsed, store)
+		}
+		from := nwr.parseGene(interaction.from)
+		to := nwr.parseGene(interaction.to)
+		if from == to {
+			continue
+		}
+		direction := "undirected"
+		if mapping.directed {
+			direction = "directed"
+		}
+		nwr.processInteraction(parsedInteraction{
+			from:        from,
+			to:          to,
+			typ:         mapping.name,
+			direction:   direction,
+			probability: interaction.probability,
+			rawTypeID:   mapping.name,
+		}, store, parsed, false)
+	}
+	return parsed
+}
@@ -0,0 +1,92 @@
+package readers
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func TestDetectNetworkFormat(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+	}{
+		{"testdata/network.sif", NetworkFormatSIF},
+		{"testdata/network.graphml", NetworkFormatGraphML},
+		{"testdata/protein.links.txt", NetworkFormatSTRING},
+		{"testdata/biogrid.tab3.txt", NetworkFormatBioGRID},
+		{"testdata/gene-ids", NetworkFormatGonetic},
+	}
+	for _, tt := range tests {
+		if format := DetectNetworkFormat(tt.fileName); format != tt.expected {
+			t.Errorf("DetectNetworkFormat(%q): expected %s, got %s", tt.fileName, tt.expected, format)
+		}
+	}
+}
+
+func TestNetworkFormats(t *testing.T) {
+	type expectedInteraction struct {
+		from, to    string
+		typ         string
+		probability float64
+		directed    bool
+		regulatory  bool
+	}
+	tests := []struct {
+		fileName     string
+		interactions []expectedInteraction
+	}{
+		{"testdata/network.sif", []expectedInteraction{
+			{"A", "B", "pp", 1, false, false},
+			{"A", "C", "pp", 1, false, false},
+			{"B", "D", "pd", 1, true, true},
+		}},
+		{"testdata/network.graphml", []expectedInteraction{
+			{"A", "B", "pp", 0.8, false, false},
+			{"B", "C", "pd", 1, true, true},
+		}},
+		{"testdata/protein.links.txt", []expectedInteraction{
+			{"9606.A", "9606.B", "string", 0.9, false, false},
+			{"9606.B", "9606.C", "string", 0.15, false, false},
+		}},
+		{"testdata/biogrid.tab3.txt", []expectedInteraction{
+			{"A", "B", "Two-hybrid", 1, false, false},
+			{"B", "C", "Synthetic_Lethality", 1, false, false},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			arguments.GlobalInteractionStore = types.NewInteractionStore()
+			gim := types.NewGeneIDMap()
+			nwr := NewInitialNetworkReader(slog.Default(), gim).WithFormat(NetworkFormatAuto, "testdata/type-mapping.tsv")
+			network := nwr.NewNetworkFromFile(tt.fileName, false, true)
+			expectedCount := 0
+			for _, expected := range tt.interactions {
+				typeID := network.InteractionStore.GetInteractionTypeID(expected.typ)
+				id := types.FromToTypeToID(gim.GetIDFromName(types.GeneName(expected.from)), gim.GetIDFromName(types.GeneName(expected.to)), typeID)
+				if !network.Probabilities().Has(id) {
+					t.Fatalf("expected interaction %s-%s of type %s", expected.from, expected.to, expected.typ)
+				}
+				if probability := network.Probabilities().GetProbability(id); probability != expected.probability {
+					t.Errorf("expected interaction %s-%s to have probability %f, got %f", expected.from, expected.to, expected.probability, probability)
+				}
+				if directed := !network.Probabilities().Has(id.Reverse()); directed != expected.directed {
+					t.Errorf("expected interaction %s-%s to be directed: %t", expected.from, expected.to, expected.directed)
+				}
+				if regulatory := network.InteractionStore.IsRegulatoryInteraction(id); regulatory != expected.regulatory {
+					t.Errorf("expected interaction %s-%s to be regulatory: %t", expected.from, expected.to, expected.regulatory)
+				}
+				expectedCount++
+				if !expected.directed {
+					expectedCount++
+				}
+			}
+			// self edges are skipped
+			if network.InteractionCount() != expectedCount {
+				t.Errorf("expected %d interactions, got %d", expectedCount, network.InteractionCount())
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
@@ -20,20 +21,45 @@ import (
 type NetworkReader struct {
 	networkLineParser
 	geneParser
+	// the format of the network files, the gonetic format if empty
+	format   string
+	mappings InteractionTypeMappings
+	// the multipliers and the merge rule of the network files
+	sources NetworkSources
 }
 
 func NewInitialNetworkReader(logger *slog.Logger, gim *types.GeneIDMap) *NetworkReader {
 	return newNetworkReader(logger, initialGeneParser{gim})
 }
 
+// WithFormat sets the format of the network files, which is detected per file for the auto format, and the mapping
+// file of the interaction labels of foreign formats onto interaction types
+func (nwr *NetworkReader) WithFormat(format, mappingFile string) *NetworkReader {
+	if format == "" {
+		format = NetworkFormatAuto
+	}
+	if !slices.Contains(NetworkFormats, format) {
+		nwr.Error("Unknown network format", "format", format, "formats", NetworkFormats)
+		panic("Cannot read networks")
+	}
+	mappings, err := ReadInteractionTypeMappings(mappingFile)
+	if err != nil {
+		nwr.Error("Failed to read interaction type mappings", "error", err, "file", mappingFile)
+		panic("Cannot read networks")
+	}
+	nwr.format = format
+	nwr.mappings = mappings
+	return nwr
+}
+
 func NewIntermediateNetworkReader(logger *slog.Logger) *NetworkReader {
 	return newNetworkReader(logger, intermediateGeneParser{})
 }
 
 func newNetworkReader(logger *slog.Logger, gp geneParser) *NetworkReader {
 	return &NetworkReader{
-		networkLineParser{logger},
-		gp,
+		networkLineParser: networkLineParser{logger},
+		geneParser:        gp,
 	}
 }
 
@@ -110,11 +136,14 @@ func (nwr NetworkReader) NewNetworkFromFiles(
 ) *graph.Network {
 	store := arguments.GlobalInteractionStore
 	combined := newParsedNetwork()
+	sources := nwr.sourcesOrDefault()
+	merger := sources.newMerger()
 
-	// Load and merge networks
+	// Load, scale and merge networks
 	for _, file := range networkFiles {
 		parsed := nwr.newWeightedNetworkFromFile(file, verbose, initial)
-		nwr.mergeNetworks(combined, parsed)
+		sources.scale(file, parsed, store)
+		nwr.mergeNetworks(combined, parsed, merger)
 	}
 
 	// Load and subtract banned interactions
@@ -128,7 +157,7 @@ func (nwr NetworkReader) NewNetworkFromFiles(
 	return graph.NewNetwork(store, combined.probabilities, combined.interactionTypes, combined.scores)
 }
 
-func (nwr NetworkReader) mergeNetworks(combined, network *parsedNetwork) {
+func (nwr NetworkReader) mergeNetworks(combined, network *parsedNetwork, merger *networkMerger) {
 	// Merge interaction types
 	for name, id := range network.interactionTypes {
 		if previous, exists := combined.interactionTypes[name]; exists && previous != id {
@@ -139,10 +168,7 @@ func (nwr NetworkReader) mergeNetworks(combined, network *parsedNetwork) {
 	}
 	// Merge probabilities
 	for id, probability := range *network.probabilities {
-		if combined.probabilities.Has(id) {
-			probability = max(combined.probabilities.GetProbability(id), probability)
-		}
-		combined.probabilities.SetProbability(id, probability)
+		merger.add(combined.probabilities, id, probability)
 	}
 	// Append scores
 	combined.scores = append(combined.scores, network.scores...)
@@ -158,6 +184,21 @@ func (nwr NetworkReader) newWeightedNetworkFromFile(
 		return nil
 	}
 	defer file.Close()
+	format := nwr.format
+	if format == NetworkFormatAuto {
+		format = DetectNetworkFormat(fileName)
+	}
+	if format != "" && format != NetworkFormatGonetic {
+		interactions, err := readSourceInteractions(format, file)
+		if err != nil {
+			nwr.Error("failed to read network file", "error", err, "format", format, "fileName", fileName)
+			panic("Cannot read network")
+		}
+		if verbose {
+			nwr.Info("Finished loading network file", "interactionCount", len(interactions), "fileName", fileName, "format", format)
+		}
+		return nwr.fromSourceInteractions(interactions)
+	}
 	scanner := bufio.NewScanner(file)
 	return nwr.fromScanner(fileName, scanner, verbose, initial)
 }
@@ -241,10 +282,16 @@ func (nwr NetworkReader) processInteraction(
 	id := types.FromToTypeToID(intx.from, intx.to, typeID)
 	store.AddInteraction(id)
 	parsed.probabilities.SetProbability(id, intx.probability)
+	if intx.sign != types.Unsigned {
+		store.SetSign(id, intx.sign)
+	}
 
 	if intx.direction != "directed" {
 		store.AddInteraction(id.Reverse())
 		parsed.probabilities.SetProbability(id.Reverse(), intx.probability)
+		if intx.sign != types.Unsigned {
+			store.SetSign(id.Reverse(), intx.sign)
+		}
 	}
 
 	parsed.interactionTypes[intx.typ] = types.NewInteractionType(intx.typ, false)
@@ -0,0 +1,165 @@
+package readers
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// merge rules for interactions that occur in several network files
+const (
+	MergeRuleMax     = "max"
+	MergeRuleNoisyOr = "noisy-or"
+	MergeRuleMean    = "mean"
+)
+
+// MergeRules are the valid merge rules of a network source config
+var MergeRules = []string{
+	MergeRuleMax,
+	MergeRuleNoisyOr,
+	MergeRuleMean,
+}
+
+// NetworkSources scales the probabilities of the interactions per interaction type and per network file, and merges
+// the interactions that occur in several network files
+type NetworkSources struct {
+	mergeRule       string
+	typeMultipliers map[string]float64
+	fileMultipliers map[string]float64
+}
+
+// NewNetworkSources creates the default network sources, which keep the probabilities and merge with the maximum
+func NewNetworkSources() NetworkSources {
+	return NetworkSources{
+		mergeRule:       MergeRuleMax,
+		typeMultipliers: make(map[string]float64),
+		fileMultipliers: make(map[string]float64),
+	}
+}
+
+// ReadNetworkSources reads a network source config with tab-separated lines, which are one of:
+//
+//	merge	max|noisy-or|mean
+//	type	<interaction type>	<multiplier>
+//	file	<network file>	<multiplier>
+//
+// The multipliers are in [0, 1], such that the scaled probabilities remain probabilities. Files match on their path or on their base name. Lines starting with # are comments.
+func ReadNetworkSources(fileName string) (NetworkSources, error) {
+	sources := NewNetworkSources()
+	if fileName == "" {
+		return sources, nil
+	}
+	for _, line := range fileio.ReadListFromFile(fileName, true) {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens := strings.Split(line, "\t")
+		switch {
+		case tokens[0] == "merge" && len(tokens) == 2:
+			if !slices.Contains(MergeRules, tokens[1]) {
+				return sources, fmt.Errorf("unknown merge rule %q, expected one of %v", tokens[1], MergeRules)
+			}
+			sources.mergeRule = tokens[1]
+		case (tokens[0] == "type" || tokens[0] == "file") && len(tokens) == 3:
+			multiplier, err := strconv.ParseFloat(tokens[2], 64)
+			// multipliers above 1 would scale probabilities above 1
+			if err != nil || multiplier < 0 || multiplier > 1 {
+				return sources, fmt.Errorf("invalid multiplier %q for %s %s, expected a number in [0, 1]", tokens[2], tokens[0], tokens[1])
+			}
+			if tokens[0] == "type" {
+				sources.typeMultipliers[tokens[1]] = multiplier
+			} else {
+				sources.fileMultipliers[tokens[1]] = multiplier
+			}
+		default:
+			return sources, fmt.Errorf("invalid network source line %q", line)
+		}
+	}
+	return sources, nil
+}
+
+// fileMultiplier returns the multiplier of the network file, which matches on its path or on its base name
+func (sources NetworkSources) fileMultiplier(fileName string) float64 {
+	if multiplier, ok := sources.fileMultipliers[fileName]; ok {
+		return multiplier
+	}
+	if multiplier, ok := sources.fileMultipliers[filepath.Base(fileName)]; ok {
+		return multiplier
+	}
+	return 1
+}
+
+// scale multiplies the probabilities of the interactions of a network file with the multipliers of the file and of
+// their interaction type
+func (sources NetworkSources) scale(fileName string, parsed *parsedNetwork, store *types.InteractionStore) {
+	fileMultiplier := sources.fileMultiplier(fileName)
+	if fileMultiplier == 1 && len(sources.typeMultipliers) == 0 {
+		return
+	}
+	for id, probability := range *parsed.probabilities {
+		multiplier := fileMultiplier
+		if typeMultiplier, ok := sources.typeMultipliers[store.InteractionType(id)]; ok {
+			multiplier *= typeMultiplier
+		}
+		parsed.probabilities.SetProbability(id, probability*multiplier)
+	}
+}
+
+// networkMerger merges the probabilities of the interactions of several network files with a merge rule
+type networkMerger struct {
+	rule  string
+	sums  map[types.InteractionID]float64
+	count map[types.InteractionID]int
+}
+
+func (sources NetworkSources) newMerger() *networkMerger {
+	return &networkMerger{
+		rule:  sources.mergeRule,
+		sums:  make(map[types.InteractionID]float64),
+		count: make(map[types.InteractionID]int),
+	}
+}
+
+// add merges the probability of an interaction into the combined probabilities
+func (merger *networkMerger) add(combined *types.ProbabilityMap, id types.InteractionID, probability float64) {
+	merger.count[id]++
+	if !combined.Has(id) {
+		merger.sums[id] = probability
+		combined.SetProbability(id, probability)
+		return
+	}
+	switch merger.rule {
+	case MergeRuleNoisyOr:
+		combined.AddBayesian(id, probability)
+	case MergeRuleMean:
+		merger.sums[id] += probability
+		combined.SetProbability(id, merger.sums[id]/float64(merger.count[id]))
+	default:
+		combined.SetProbability(id, max(combined.GetProbability(id), probability))
+	}
+}
+
+// WithSources sets the network source config, which scales the probabilities per interaction type and per network
+// file, and sets the merge rule of interactions that occur in several network files
+func (nwr *NetworkReader) WithSources(configFile string) *NetworkReader {
+	sources, err := ReadNetworkSources(configFile)
+	if err != nil {
+		nwr.Error("Failed to read network sources", "error", err, "file", configFile)
+		panic("Cannot read networks")
+	}
+	nwr.sources = sources
+	return nwr
+}
+
+// sourcesOrDefault returns the network sources, the default sources when they were not set
+func (nwr NetworkReader) sourcesOrDefault() NetworkSources {
+	if nwr.sources.typeMultipliers == nil {
+		return NewNetworkSources()
+	}
+	return nwr.sources
+}
@@ -0,0 +1,78 @@
+package readers
+
+import (
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func TestNetworkSources(t *testing.T) {
+	outputDir := "testresult/NetworkSources"
+	fileio.CreateEmptyDir(outputDir)
+	files := map[string]string{
+		"first.sif":  "A\tpp\tB\nB\tpd\tC\n",
+		"second.sif": "A\tpp\tB\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte(content), 0666); err != nil {
+			t.Fatalf("failed to write network: %v", err)
+		}
+	}
+	networkFiles := []string{filepath.Join(outputDir, "first.sif"), filepath.Join(outputDir, "second.sif")}
+
+	tests := []struct {
+		mergeRule string
+		expected  float64
+	}{
+		// A-B has probability 0.5 in the first file and 0.5 * 0.6 in the second file
+		{MergeRuleMax, 0.5},
+		{MergeRuleNoisyOr, 1 - 0.5*0.7},
+		{MergeRuleMean, 0.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mergeRule, func(t *testing.T) {
+			configFile := filepath.Join(outputDir, tt.mergeRule+".tsv")
+			config := "# network sources\nmerge\t" + tt.mergeRule + "\ntype\tpp\t0.5\ntype\tpd\t0.25\nfile\tsecond.sif\t0.6\n"
+			if err := os.WriteFile(configFile, []byte(config), 0666); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			arguments.GlobalInteractionStore = types.NewInteractionStore()
+			gim := types.NewGeneIDMap()
+			nwr := NewInitialNetworkReader(slog.Default(), gim).WithFormat(NetworkFormatSIF, "").WithSources(configFile)
+			network := nwr.NewNetworkFromFiles(networkFiles, nil, false, true)
+			probability := func(from, to, typ string) float64 {
+				typeID := network.InteractionStore.GetInteractionTypeID(typ)
+				return network.Probabilities().GetProbability(types.FromToTypeToID(gim.GetIDFromName(types.GeneName(from)), gim.GetIDFromName(types.GeneName(to)), typeID))
+			}
+			if p := probability("A", "B", "pp"); math.Abs(p-tt.expected) > 1e-9 {
+				t.Errorf("expected A-B to have probability %f, got %f", tt.expected, p)
+			}
+			if p := probability("B", "A", "pp"); math.Abs(p-tt.expected) > 1e-9 {
+				t.Errorf("expected B-A to have probability %f, got %f", tt.expected, p)
+			}
+			if p := probability("B", "C", "pd"); math.Abs(p-0.25) > 1e-9 {
+				t.Errorf("expected B-C to have probability 0.25, got %f", p)
+			}
+		})
+	}
+}
+
+func TestReadNetworkSourcesErrors(t *testing.T) {
+	outputDir := "testresult/NetworkSources"
+	fileio.CreateEmptyDir(outputDir)
+	for _, config := range []string{"merge\tmin\n", "type\tpp\tmany\n", "type\tpp\t-0.5\n", "type\tpp\t1.5\n", "file\tnetwork.sif\t2\n", "file\tnetwork.sif\n", "weight\tpp\t0.5\n"} {
+		configFile := filepath.Join(outputDir, "invalid.tsv")
+		if err := os.WriteFile(configFile, []byte(config), 0666); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := ReadNetworkSources(configFile); err == nil {
+			t.Errorf("expected an error for config %q", config)
+		}
+	}
+}
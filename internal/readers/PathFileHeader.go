@@ -0,0 +1,323 @@
+package readers
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// The path file format is a tab-separated file with one path per line, preceded by a header.
+// Every header line starts with headerPrefix and holds a key and a value, separated by a tab.
+// The first header line holds the format name and its version.
+const (
+	headerPrefix       = "#"
+	pathFileFormat     = "gonetic-paths"
+	PathFileVersion    = 1
+	pathTypeKey        = "path-type"
+	pathLengthKey      = "path-length"
+	bestPathCountKey   = "best-path-count"
+	pathPatternKey     = "path-pattern"
+	sldCutoffKey       = "search-tree-cutoff"
+	weightingKey       = "topology-weighting-addition"
+	weightingMethodKey = "topology-weighting-method"
+	minEdgeScoreKey    = "min-edge-score"
+	geneIndexKey       = "gene-index"
+	interactionIdxKey  = "interaction-type-index"
+	networkKey         = "network"
+	sampleWeightingKey = "sample-weighting"
+	signConsistentKey  = "sign-consistent"
+)
+
+// headerKeys is the order of the keys in the header
+var headerKeys = []string{
+	pathTypeKey,
+	pathLengthKey,
+	bestPathCountKey,
+	pathPatternKey,
+	sldCutoffKey,
+	weightingKey,
+	weightingMethodKey,
+	minEdgeScoreKey,
+	geneIndexKey,
+	interactionIdxKey,
+	networkKey,
+	sampleWeightingKey,
+	signConsistentKey,
+}
+
+// informativeKeys are recorded in the header, but do not make a path file incompatible
+// e.g. the search tree cutoff is only a lower bound on the path probabilities, and can be raised when reading paths
+var informativeKeys = map[string]struct{}{
+	sldCutoffKey: {},
+}
+
+// PathFileHeader records the version of the path file format and the settings that produced the paths
+type PathFileHeader struct {
+	Version int
+	Fields  map[string]string
+}
+
+// NewPathFileHeader creates the header for the paths of the given type found with the current settings, the QTL
+// settings are nil in the expression setting and the EQTL settings are nil outside the EQTL setting
+func NewPathFileHeader(args *arguments.Common, qtlArgs *arguments.QTLSpecific, eqtlArgs *arguments.EQTL, pathType string) PathFileHeader {
+	interactionTypes := args.InteractionStore.InteractionTypes()
+	return PathFileHeader{
+		Version: PathFileVersion,
+		Fields: map[string]string{
+			pathTypeKey:        pathType,
+			pathLengthKey:      strconv.Itoa(args.PathLength),
+			bestPathCountKey:   strconv.Itoa(args.BestPathCount),
+			pathPatternKey:     args.PathPattern,
+			sldCutoffKey:       strconv.FormatFloat(args.SldCutoff, 'f', -1, 64),
+			weightingKey:       args.TopologyWeightingAddition,
+			weightingMethodKey: topologyWeightingField(args),
+			minEdgeScoreKey:    strconv.FormatFloat(args.MinEdgeScore, 'f', -1, 64),
+			geneIndexKey:       indexField(args.GeneIDMap.MaxID(), args.GeneIDMap.Hash(args.GeneIDMap.MaxID())),
+			interactionIdxKey:  indexField(interactionTypes.MaxID(), interactionTypes.Hash(interactionTypes.MaxID())),
+			networkKey:         networkHash(args),
+			sampleWeightingKey: sampleWeightingHash(qtlArgs, pathType),
+			signConsistentKey:  signConsistentField(eqtlArgs, pathType),
+		},
+	}
+}
+
+// Lines returns the header lines
+func (header PathFileHeader) Lines() []string {
+	lines := make([]string, 0, len(headerKeys)+1)
+	lines = append(lines, fmt.Sprintf("%s%s\t%d", headerPrefix, pathFileFormat, header.Version))
+	for _, key := range headerKeys {
+		lines = append(lines, fmt.Sprintf("%s%s\t%s", headerPrefix, key, header.Fields[key]))
+	}
+	return lines
+}
+
+// isHeaderLine returns whether the line is part of the header of a path file
+func isHeaderLine(line string) bool {
+	return strings.HasPrefix(line, headerPrefix)
+}
+
+// ReadPathFileHeader reads the header of the path file, ok is false for path files without a header
+func ReadPathFileHeader(fileName string) (header PathFileHeader, ok bool, err error) {
+	file, err := fileio.OpenDecompressedFile(fileio.ResolveCompressedFile(fileName))
+	if err != nil {
+		return header, false, err
+	}
+	defer file.Close()
+	return parsePathFileHeader(file)
+}
+
+func parsePathFileHeader(reader io.Reader) (header PathFileHeader, ok bool, err error) {
+	scanner := bufio.NewScanner(reader)
+	header.Fields = make(map[string]string)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isHeaderLine(line) {
+			break
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(line, headerPrefix), "\t")
+		if !ok {
+			// the first line identifies the format
+			if key != pathFileFormat {
+				return header, false, fmt.Errorf("unknown path file format %q", key)
+			}
+			header.Version, err = strconv.Atoi(value)
+			if err != nil {
+				return header, false, fmt.Errorf("invalid path file version %q: %w", value, err)
+			}
+			ok = true
+			continue
+		}
+		header.Fields[key] = value
+	}
+	return header, ok, scanner.Err()
+}
+
+// Incompatibilities lists the differences between the header and the current settings that make the paths unusable
+func (header PathFileHeader) Incompatibilities(args *arguments.Common, qtlArgs *arguments.QTLSpecific, eqtlArgs *arguments.EQTL, pathType string) []string {
+	incompatibilities := make([]string, 0)
+	if header.Version != PathFileVersion {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("version %d, expected %d", header.Version, PathFileVersion))
+	}
+	current := NewPathFileHeader(args, qtlArgs, eqtlArgs, pathType)
+	for _, key := range headerKeys {
+		if _, ok := informativeKeys[key]; ok {
+			continue
+		}
+		field, ok := header.Fields[key]
+		if !ok {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s missing, expected %q", key, current.Fields[key]))
+			continue
+		}
+		var compatible bool
+		switch key {
+		case geneIndexKey:
+			compatible = indexCompatible(header.Fields[key], args.GeneIDMap)
+		case interactionIdxKey:
+			compatible = indexCompatible(header.Fields[key], args.InteractionStore.InteractionTypes())
+		default:
+			compatible = field == current.Fields[key]
+		}
+		if !compatible {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s %q, expected %q", key, field, current.Fields[key]))
+		}
+	}
+	return incompatibilities
+}
+
+// topologyWeightingField records the topology weighting method together with its parameter
+func topologyWeightingField(args *arguments.Common) string {
+	switch args.TopologyWeightingMethod {
+	case "", "degree":
+		if args.HubCentrality == "" || args.HubCentrality == "out-degree" {
+			return "degree"
+		}
+		return fmt.Sprintf("degree:%s", args.HubCentrality)
+	case "rwr":
+		return fmt.Sprintf("rwr:%s", strconv.FormatFloat(args.PropagationRestart, 'f', -1, 64))
+	case "heat":
+		return fmt.Sprintf("heat:%s", strconv.FormatFloat(args.HeatKernelTime, 'f', -1, 64))
+	default:
+		return args.TopologyWeightingMethod
+	}
+}
+
+// indexField records the size and the hash of an index
+func indexField[ID ~uint64](maxID ID, hash string) string {
+	return fmt.Sprintf("%d:%s", maxID, hash)
+}
+
+// indexCompatible returns whether the index contains the recorded index, possibly extended with new names
+func indexCompatible[ID ~uint64, Name ~string](field string, index *types.IDMap[ID, Name]) bool {
+	maxIDString, hash, found := strings.Cut(field, ":")
+	if !found {
+		return false
+	}
+	maxID, err := strconv.ParseUint(maxIDString, 10, 64)
+	if err != nil || ID(maxID) > index.MaxID() {
+		return false
+	}
+	return index.Hash(ID(maxID)) == hash
+}
+
+// networkHash hashes the content of the network files and the banned network files, in the given order, the format in
+// which they are read and the interaction type mapping if there is one, the network source config if there is one, and
+// the settings that remove genes from the network if they are used
+func networkHash(args *arguments.Common) string {
+	hash := sha256.New()
+	for _, files := range [][]string{args.NetworkFiles, args.BannedNetworkFiles} {
+		for _, fileName := range files {
+			content, err := os.ReadFile(fileName)
+			if err != nil {
+				fmt.Fprintf(hash, "unreadable\t%s\n", fileName)
+				continue
+			}
+			fmt.Fprintf(hash, "%d\n", len(content))
+			hash.Write(content)
+		}
+		hash.Write([]byte("banned\n"))
+	}
+	format := args.NetworkFormat
+	if format == "" {
+		format = NetworkFormatAuto
+	}
+	fmt.Fprintf(hash, "format\t%s\n", format)
+	if mappingFile := args.NetworkTypeMapping; mappingFile != "" {
+		content, err := os.ReadFile(mappingFile)
+		if err != nil {
+			fmt.Fprintf(hash, "unreadable\t%s\n", mappingFile)
+		}
+		fmt.Fprintf(hash, "mapping\t%d\n", len(content))
+		hash.Write(content)
+	}
+	if sourcesFile := args.NetworkSources; sourcesFile != "" {
+		content, err := os.ReadFile(sourcesFile)
+		if err != nil {
+			fmt.Fprintf(hash, "unreadable\t%s\n", sourcesFile)
+		}
+		fmt.Fprintf(hash, "sources\t%d\n", len(content))
+		hash.Write(content)
+	}
+	if len(args.BannedGenes) > 0 || args.MaxDegree > 0 {
+		fmt.Fprintf(hash, "removed\t%s\t%d\n", strings.Join(args.BannedGenes, "\t"), args.MaxDegree)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// sampleWeightingHash hashes the content of the sample weighting file and the settings that weight the per-sample
+// networks, if the paths of the given type are searched on the per-sample networks
+func sampleWeightingHash(qtlArgs *arguments.QTLSpecific, pathType string) string {
+	if qtlArgs == nil || qtlArgs.SampleWeightingFile == "" {
+		return "none"
+	}
+	switch pathType {
+	case "mutation", "cna":
+	default:
+		return "none"
+	}
+	hash := sha256.New()
+	content, err := os.ReadFile(qtlArgs.SampleWeightingFile)
+	if err != nil {
+		fmt.Fprintf(hash, "unreadable\t%s\n", qtlArgs.SampleWeightingFile)
+	}
+	fmt.Fprintf(hash, "%d\n", len(content))
+	hash.Write(content)
+	fmt.Fprintf(hash, "%s\t%s\t%s\n",
+		qtlArgs.SampleWeightingMethod,
+		qtlArgs.SampleWeightingAddition,
+		strconv.FormatFloat(qtlArgs.SampleWeightingDefault, 'f', -1, 64),
+	)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// signConsistentField records whether only the sign consistent paths are kept, which only applies to the eqtl paths
+func signConsistentField(eqtlArgs *arguments.EQTL, pathType string) string {
+	if eqtlArgs == nil || pathType != "eqtl" {
+		return "false"
+	}
+	return strconv.FormatBool(eqtlArgs.SignConsistent)
+}
+
+// CheckPathFiles verifies that the path files in --use-paths were produced with compatible settings.
+// Incompatible path files are refused, unless --force-paths is used. Path files without a header predate the
+// versioned format and cannot be verified. The QTL settings are nil in the expression setting, the EQTL settings are nil
+// outside the EQTL setting.
+func CheckPathFiles(args *arguments.Common, qtlArgs *arguments.QTLSpecific, eqtlArgs *arguments.EQTL) {
+	if args.UsePaths == "" {
+		return
+	}
+	for _, pathType := range args.PathTypes {
+		fileName := args.PathsFile(pathType)
+		header, ok, err := ReadPathFileHeader(fileName)
+		if err != nil {
+			args.Error("Failed to read path file header", "file", fileName, "err", err)
+			if !args.ForcePaths {
+				panic("Cannot use paths")
+			}
+			continue
+		}
+		if !ok {
+			args.Warn("Path file has no header, its compatibility cannot be verified", "file", fileName)
+			continue
+		}
+		incompatibilities := header.Incompatibilities(args, qtlArgs, eqtlArgs, pathType)
+		if len(incompatibilities) == 0 {
+			args.Info("Path file is compatible", "file", fileName, "version", header.Version)
+			continue
+		}
+		if args.ForcePaths {
+			args.Warn("Using incompatible path file", "file", fileName, "incompatibilities", incompatibilities)
+			continue
+		}
+		args.Error("Incompatible path file, use --force-paths to use it anyway", "file", fileName, "incompatibilities", incompatibilities)
+		panic("Cannot use incompatible paths")
+	}
+}
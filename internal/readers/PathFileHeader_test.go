@@ -0,0 +1,237 @@
+package readers
+
+import (
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func headerArgs(networkFile string) *arguments.Common {
+	args := arguments.NewCommon()
+	args.PathLength = 3
+	args.BestPathCount = 25
+	args.TopologyWeightingAddition = "bayes"
+	args.NetworkFiles = []string{networkFile}
+	args.GeneIDMap = types.NewGeneIDMap()
+	args.GeneIDMap.SetName("a")
+	args.GeneIDMap.SetName("b")
+	args.InteractionStore = types.NewInteractionStore()
+	return args
+}
+
+func TestPathFileHeader(t *testing.T) {
+	outputDir := "testresult/PathFileHeader"
+	fileio.CreateEmptyDir(outputDir)
+	networkFile := filepath.Join(outputDir, "network.csv")
+	if err := os.WriteFile(networkFile, []byte("a\tb\tpp\tundirected\n"), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	args := headerArgs(networkFile)
+	lines := NewPathFileHeader(args, nil, nil, "mutation").Lines()
+
+	// the header can be read back
+	header, ok, err := parsePathFileHeader(strings.NewReader(strings.Join(append(lines, "path"), "\n")))
+	if err != nil || !ok {
+		t.Fatalf("failed to parse header: %v", err)
+	}
+	if header.Version != PathFileVersion {
+		t.Errorf("expected version %d, got %d", PathFileVersion, header.Version)
+	}
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+
+	// a different search tree cutoff is allowed, and so are new genes in the index
+	args.SldCutoff = 0.5
+	args.GeneIDMap.SetName("c")
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+
+	// the topology weighting method and its parameter must match
+	args.TopologyWeightingMethod = "rwr"
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the topology weighting method to be incompatible, got %v", incompatibilities)
+	}
+	args.TopologyWeightingMethod = ""
+	args.HubCentrality = "betweenness"
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the hub centrality to be incompatible, got %v", incompatibilities)
+	}
+	args.HubCentrality = "out-degree"
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 0 {
+		t.Errorf("expected the default hub centrality to be compatible, got %v", incompatibilities)
+	}
+	args.HubCentrality = ""
+
+	// a header without one of the keys is incompatible
+	withoutKey := PathFileHeader{Version: header.Version, Fields: maps.Clone(header.Fields)}
+	delete(withoutKey.Fields, weightingMethodKey)
+	if incompatibilities := withoutKey.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the missing topology weighting method to be incompatible, got %v", incompatibilities)
+	}
+
+	// other settings, another index or another network are incompatible
+	args.PathLength = 4
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the path length to be incompatible, got %v", incompatibilities)
+	}
+	args = headerArgs(networkFile)
+	args.GeneIDMap = types.NewGeneIDMap()
+	args.GeneIDMap.SetName("b")
+	args.GeneIDMap.SetName("a")
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the gene index to be incompatible, got %v", incompatibilities)
+	}
+	args = headerArgs(networkFile)
+	if err := os.WriteFile(networkFile, []byte("a\tb\tpp\tdirected\n"), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the network to be incompatible, got %v", incompatibilities)
+	}
+
+	// the header is skipped when reading paths
+	pathsFile := filepath.Join(outputDir, "mutation.paths")
+	fw := fileio.FileWriter{Logger: slog.Default()}
+	if err := fw.WriteLinesToNewFile(pathsFile, lines, fileio.ReadListFromFile(filepath.Join("testdata", "mutation.paths"), false)); err != nil {
+		t.Fatalf("failed to write paths: %v", err)
+	}
+	gim := ReadIDMap[types.GeneID, types.GeneName]("testdata/gene-ids")
+	expected := ReadPathList(slog.Default(), gim, math.MaxInt, "test", 0.0, filepath.Join("testdata", "mutation.paths"))
+	paths := ReadPathList(slog.Default(), gim, math.MaxInt, "test", 0.0, pathsFile)
+	if len(paths) != len(expected) {
+		t.Errorf("expected %d headers, got %d", len(expected), len(paths))
+	}
+
+	// path files without a header predate the versioned format
+	_, ok, err = ReadPathFileHeader(filepath.Join("testdata", "mutation.paths"))
+	if err != nil || ok {
+		t.Errorf("expected no header, got ok %v and error %v", ok, err)
+	}
+}
+
+func TestPathFileHeaderSampleWeighting(t *testing.T) {
+	outputDir := "testresult/PathFileHeaderSampleWeighting"
+	fileio.CreateEmptyDir(outputDir)
+	networkFile := filepath.Join(outputDir, "network.csv")
+	if err := os.WriteFile(networkFile, []byte("a\tb\tpp\tundirected\n"), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	sampleFile := filepath.Join(outputDir, "samples.txt")
+	if err := os.WriteFile(sampleFile, []byte("gene name\tcondition\tactivity\na\ts1\t0.5\n"), 0666); err != nil {
+		t.Fatalf("failed to write sample weighting file: %v", err)
+	}
+	args := headerArgs(networkFile)
+	qtlArgs := &arguments.QTLSpecific{
+		SampleWeightingFile:     sampleFile,
+		SampleWeightingMethod:   "activity",
+		SampleWeightingAddition: "mean",
+	}
+	header := NewPathFileHeader(args, qtlArgs, nil, "mutation")
+	if incompatibilities := header.Incompatibilities(args, qtlArgs, nil, "mutation"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+
+	// paths found without sample weighting are incompatible
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the sample weighting to be incompatible, got %v", incompatibilities)
+	}
+
+	// another weighting method or other sample data are incompatible
+	qtlArgs.SampleWeightingMethod = "lfc"
+	if incompatibilities := header.Incompatibilities(args, qtlArgs, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the sample weighting method to be incompatible, got %v", incompatibilities)
+	}
+	qtlArgs.SampleWeightingMethod = "activity"
+	if err := os.WriteFile(sampleFile, []byte("gene name\tcondition\tactivity\na\ts1\t0.7\n"), 0666); err != nil {
+		t.Fatalf("failed to write sample weighting file: %v", err)
+	}
+	if incompatibilities := header.Incompatibilities(args, qtlArgs, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the sample weighting file to be incompatible, got %v", incompatibilities)
+	}
+
+	// the paths of the expression path type are not searched on the sample networks
+	header = NewPathFileHeader(args, nil, nil, "expression")
+	if incompatibilities := header.Incompatibilities(args, qtlArgs, nil, "expression"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+}
+
+func TestPathFileHeaderSignConsistent(t *testing.T) {
+	outputDir := "testresult/PathFileHeaderSignConsistent"
+	fileio.CreateEmptyDir(outputDir)
+	networkFile := filepath.Join(outputDir, "network.csv")
+	if err := os.WriteFile(networkFile, []byte("a\tb\tpp\tundirected\n"), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	args := headerArgs(networkFile)
+	eqtlArgs := &arguments.EQTL{SignConsistent: true}
+	header := NewPathFileHeader(args, nil, eqtlArgs, "eqtl")
+	if field := header.Fields[signConsistentKey]; field != "true" {
+		t.Errorf("expected the sign consistency to be recorded, got %q", field)
+	}
+	if incompatibilities := header.Incompatibilities(args, nil, eqtlArgs, "eqtl"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+
+	// paths found without the sign consistency filter are incompatible
+	if incompatibilities := header.Incompatibilities(args, nil, &arguments.EQTL{}, "eqtl"); len(incompatibilities) != 1 {
+		t.Errorf("expected the sign consistency to be incompatible, got %v", incompatibilities)
+	}
+
+	// the filter only applies to the eqtl paths
+	header = NewPathFileHeader(args, nil, nil, "mutation")
+	if incompatibilities := header.Incompatibilities(args, nil, eqtlArgs, "mutation"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+}
+
+func TestPathFileHeaderNetworkFormat(t *testing.T) {
+	outputDir := "testresult/PathFileHeaderNetworkFormat"
+	fileio.CreateEmptyDir(outputDir)
+	networkFile := filepath.Join(outputDir, "network.sif")
+	if err := os.WriteFile(networkFile, []byte("a\tpp\tb\n"), 0666); err != nil {
+		t.Fatalf("failed to write network: %v", err)
+	}
+	mappingFile := filepath.Join(outputDir, "mapping.tsv")
+	if err := os.WriteFile(mappingFile, []byte("pp\tpp\tnon-regulatory\tundirected\n"), 0666); err != nil {
+		t.Fatalf("failed to write mapping: %v", err)
+	}
+	args := headerArgs(networkFile)
+	header := NewPathFileHeader(args, nil, nil, "mutation")
+
+	// the default format is the auto format
+	args.NetworkFormat = NetworkFormatAuto
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 0 {
+		t.Errorf("expected a compatible header, got %v", incompatibilities)
+	}
+
+	// the same file read in another format is incompatible
+	args.NetworkFormat = NetworkFormatSIF
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the network format to be incompatible, got %v", incompatibilities)
+	}
+
+	// so is the same file read with an interaction type mapping, or with another mapping
+	args.NetworkFormat = NetworkFormatAuto
+	args.NetworkTypeMapping = mappingFile
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the interaction type mapping to be incompatible, got %v", incompatibilities)
+	}
+	header = NewPathFileHeader(args, nil, nil, "mutation")
+	if err := os.WriteFile(mappingFile, []byte("pp\tpd\tregulatory\tdirected\n"), 0666); err != nil {
+		t.Fatalf("failed to write mapping: %v", err)
+	}
+	if incompatibilities := header.Incompatibilities(args, nil, nil, "mutation"); len(incompatibilities) != 1 {
+		t.Errorf("expected the changed interaction type mapping to be incompatible, got %v", incompatibilities)
+	}
+}
@@ -0,0 +1,45 @@
+package readers
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// PermuteMutations reassigns the mutated genes of each condition to random genes from the given genes, such that
+// every condition keeps its number of mutated genes. All mutations of a gene in a condition move to the same random
+// gene, together with their other data, e.g. their frequency increase and functional score.
+func PermuteMutations(mutationFileData FileData, genes []string) (FileData, error) {
+	geneColumn := mutationFileData.Headers["gene name"]
+	conditionColumn := mutationFileData.Headers["condition"]
+	// the distinct mutated genes per condition, in order of appearance
+	mutatedPerCondition := make(map[string][]string)
+	for _, entry := range mutationFileData.Entries {
+		condition := entry[conditionColumn]
+		if !slices.Contains(mutatedPerCondition[condition], entry[geneColumn]) {
+			mutatedPerCondition[condition] = append(mutatedPerCondition[condition], entry[geneColumn])
+		}
+	}
+	// draw the new genes of each condition without replacement
+	permutedPerCondition := make(map[string]map[string]string, len(mutatedPerCondition))
+	for condition, mutated := range mutatedPerCondition {
+		if len(mutated) > len(genes) {
+			return FileData{}, fmt.Errorf("condition %s has %d mutated genes, but only %d genes can be drawn", condition, len(mutated), len(genes))
+		}
+		permutedPerCondition[condition] = make(map[string]string, len(mutated))
+		for i, j := range rand.Perm(len(genes))[:len(mutated)] {
+			permutedPerCondition[condition][mutated[i]] = genes[j]
+		}
+	}
+	permuted := FileData{
+		ID:      mutationFileData.ID,
+		Headers: mutationFileData.Headers,
+		Entries: make([][]string, 0, len(mutationFileData.Entries)),
+	}
+	for _, entry := range mutationFileData.Entries {
+		permutedEntry := slices.Clone(entry)
+		permutedEntry[geneColumn] = permutedPerCondition[entry[conditionColumn]][entry[geneColumn]]
+		permuted.Entries = append(permuted.Entries, permutedEntry)
+	}
+	return permuted, nil
+}
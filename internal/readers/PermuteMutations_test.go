@@ -0,0 +1,68 @@
+package readers
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPermuteMutations(t *testing.T) {
+	mutations := FileData{
+		ID:      "mutation",
+		Headers: map[string]int{"gene name": 0, "condition": 1, "functional score": 2},
+		Entries: [][]string{
+			{"a", "s1", "0.1"},
+			{"a", "s1", "0.2"},
+			{"b", "s1", "0.3"},
+			{"a", "s2", "0.4"},
+		},
+	}
+	genes := make([]string, 0, 20)
+	for i := range 20 {
+		genes = append(genes, fmt.Sprintf("g%d", i))
+	}
+
+	for range 10 {
+		permuted, err := PermuteMutations(mutations, genes)
+		if err != nil {
+			t.Fatalf("failed to permute mutations: %v", err)
+		}
+		if len(permuted.Entries) != len(mutations.Entries) {
+			t.Fatalf("expected %d mutations, got %d", len(mutations.Entries), len(permuted.Entries))
+		}
+		for i, entry := range permuted.Entries {
+			// the other data moves with the mutation
+			if entry[1] != mutations.Entries[i][1] || entry[2] != mutations.Entries[i][2] {
+				t.Errorf("expected mutation %v to keep its condition and score, got %v", mutations.Entries[i], entry)
+			}
+		}
+		// all mutations of a gene in a condition move to the same gene, and each condition keeps its gene count
+		if permuted.Entries[0][0] != permuted.Entries[1][0] {
+			t.Errorf("expected the mutations of a gene to move together, got %s and %s", permuted.Entries[0][0], permuted.Entries[1][0])
+		}
+		if permuted.Entries[0][0] == permuted.Entries[2][0] {
+			t.Errorf("expected distinct genes to stay distinct, got %s twice", permuted.Entries[0][0])
+		}
+	}
+	// the original data is left untouched
+	if mutations.Entries[0][0] != "a" {
+		t.Errorf("expected the original mutations to be unchanged, got %v", mutations.Entries[0])
+	}
+
+	if _, err := PermuteMutations(mutations, genes[:1]); err == nil {
+		t.Errorf("expected an error when too few genes can be drawn")
+	}
+
+	// copy-number alterations are permuted in the same way, and absent copy-number data stays empty
+	cnas := FileData{
+		ID:      "cna",
+		Headers: map[string]int{"gene name": 0, "condition": 1, "copy number": 2, "focality": 3},
+		Entries: [][]string{{"a", "s1", "-2", "focal"}},
+	}
+	permuted, err := PermuteMutations(cnas, genes)
+	if err != nil || len(permuted.Entries) != 1 || permuted.Entries[0][2] != "-2" {
+		t.Errorf("expected the copy-number alteration to be permuted, got %v and error %v", permuted.Entries, err)
+	}
+	if permuted, err := PermuteMutations(FileData{}, genes); err != nil || len(permuted.Entries) != 0 {
+		t.Errorf("expected no copy-number alterations, got %v and error %v", permuted.Entries, err)
+	}
+}
@@ -0,0 +1,99 @@
+package readers
+
+import (
+	"bufio"
+	"log"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+)
+
+// gisticGeneColumns are the columns of a GISTIC gene table that precede the sample columns
+const gisticGeneColumns = 3
+
+// ReadCNAFile reads gene-level copy-number calls, e.g. GISTIC thresholded values from -2 to 2. The file is either a
+// separated file like the mutation file, with the columns gene name, condition, copy number and the optional
+// focality, or a GISTIC table (all_thresholded.by_genes.txt) with the columns Gene Symbol, Locus ID, Cytoband and a
+// column per sample, which can be gzip compressed. Genes without an alteration, with copy number 0, are skipped.
+func ReadCNAFile(logger *slog.Logger, fileName string) FileData {
+	if fileName == "" {
+		return FileData{}
+	}
+	var data FileData
+	if isGisticTable(fileName) {
+		data = readGisticTable(logger, fileName)
+	} else {
+		headers := []string{"gene name", "condition", "copy number", "focality"}
+		required := []string{"gene name", "condition", "copy number"}
+		data = ReadSeparatedFile(logger, fileName, "cna", headers, required)
+	}
+	altered := make([][]string, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		copyNumber, err := strconv.ParseFloat(entry[data.Headers["copy number"]], 64)
+		if err != nil {
+			logger.Error("Failed to parse copy number", "entry", entry, "fileName", fileName)
+			continue
+		}
+		if copyNumber != 0 {
+			altered = append(altered, entry)
+		}
+	}
+	data.Entries = altered
+	logger.Info("Finished reading copy-number alterations", "fileName", fileName, "alterations", len(altered))
+	return data
+}
+
+// isGisticTable returns whether the first line of the file is the header of a GISTIC gene table
+func isGisticTable(fileName string) bool {
+	file, err := fileio.OpenDecompressedFile(fileName)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxVariantLineLength)
+	return scanner.Scan() && strings.HasPrefix(scanner.Text(), "Gene Symbol")
+}
+
+// readGisticTable converts a GISTIC gene table into gene name, condition and copy number entries
+func readGisticTable(logger *slog.Logger, fileName string) FileData {
+	file, err := fileio.OpenDecompressedFile(fileName)
+	if err != nil {
+		logger.Error("Failed to open CNA file", "error", err, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxVariantLineLength)
+	data := FileData{
+		ID:      "cna",
+		Headers: map[string]int{"gene name": 0, "condition": 1, "copy number": 2},
+		Entries: make([][]string, 0),
+	}
+	var samples []string
+	for scanner.Scan() {
+		tokens := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "\t")
+		if samples == nil {
+			if len(tokens) <= gisticGeneColumns {
+				logger.Error("GISTIC table has no sample columns", "fileName", fileName)
+				log.Panic("unrecoverable error")
+			}
+			samples = tokens[gisticGeneColumns:]
+			continue
+		}
+		if len(tokens) != len(samples)+gisticGeneColumns {
+			logger.Error("Incorrect number of columns", "fileName", fileName, "gene", tokens[0])
+			continue
+		}
+		for i, sample := range samples {
+			data.Entries = append(data.Entries, []string{tokens[0], sample, tokens[gisticGeneColumns+i]})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		logger.Error("Failed to read CNA file", "error", err, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	return data
+}
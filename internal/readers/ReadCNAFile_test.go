@@ -0,0 +1,24 @@
+package readers
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestReadCNAFile(t *testing.T) {
+	data := ReadCNAFile(slog.Default(), "testdata/cna.gistic.txt")
+	if data.ID != "cna" {
+		t.Errorf("expected the ID cna, got %s", data.ID)
+	}
+	lines := make([]string, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		lines = append(lines, entry[data.Headers["gene name"]]+"\t"+entry[data.Headers["condition"]]+"\t"+entry[data.Headers["copy number"]])
+	}
+	slices.Sort(lines)
+	// genes without an alteration are skipped
+	expected := []string{"MYC\tTCGA-01\t2", "PTEN\tTCGA-01\t-1", "PTEN\tTCGA-02\t-2"}
+	if !slices.Equal(lines, expected) {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+}
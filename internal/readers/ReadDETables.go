@@ -0,0 +1,222 @@
+package readers
+
+import (
+	"encoding/csv"
+	"io"
+	"log"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// the columns of the expression data read from differential expression tables
+var deTableHeaders = map[string]int{"gene name": 0, "condition": 1, "p value": 2, "padj": 3, "lfc": 4, "zscore": 5}
+
+// the column names of the DESeq2 and edgeR result tables, lower case
+var (
+	deTableGeneColumns  = []string{"gene name", "gene", "gene_name", "genes", "symbol", "gene_id"}
+	deTableLFCColumns   = []string{"log2foldchange", "logfc"}
+	deTablePValueColumn = "pvalue"
+	deTablePadjColumns  = []string{"padj", "fdr"}
+	deTableStatColumn   = "stat"
+)
+
+// ReadExpressionData reads the expression data and the differentially expressed genes of the expression arguments.
+// When differential expression tables are given, they replace the expression file, and the differentially expressed
+// genes are called from their adjusted p-values and log fold changes unless a differential expression file is given.
+func ReadExpressionData(args *arguments.Expression) (FileData, FileData) {
+	if len(args.DETables) == 0 {
+		return ReadExpressionFile(args.Logger, args.ExpressionFile, args.ExpressionWeightingMethod),
+			ReadDifferentialExpressionFile(args.Logger, args.DifferentialExpressionList)
+	}
+	if args.ExpressionFile != "" {
+		args.Error("Use either --expression-file or --de-tables", "expressionFile", args.ExpressionFile, "deTables", args.DETables)
+		log.Panic("unrecoverable error")
+	}
+	switch args.ExpressionWeightingMethod {
+	case "none", "lfc", "zscore":
+	default:
+		args.Error("Differential expression tables only support the none, lfc and zscore weighting methods", "method", args.ExpressionWeightingMethod)
+		log.Panic("unrecoverable error")
+	}
+	expressionFileData := ReadDETables(args.Logger, args.DETables)
+	if args.DifferentialExpressionList != "" {
+		return expressionFileData, ReadDifferentialExpressionFile(args.Logger, args.DifferentialExpressionList)
+	}
+	return expressionFileData, CallDifferentialExpression(args.Logger, expressionFileData, args.PadjCutoff, args.LFCCutoff)
+}
+
+// ReadDETables reads DESeq2 or edgeR result tables, comma or tab separated and optionally gzip compressed, as
+// expression data with the columns gene name, condition, p value, padj, lfc and zscore. Each table is a file name,
+// optionally prefixed by its condition as condition=fileName. The condition of a gene is the condition of its table,
+// else the condition column of the table, else the file name without extensions. The gene is the first column with a
+// gene name, or the row names. The lfc is log2FoldChange (DESeq2) or logFC (edgeR), and the padj is padj (DESeq2) or
+// FDR (edgeR). The zscore is the Wald statistic of DESeq2, and is derived from the p-value and the sign of the lfc for
+// edgeR. Genes without an lfc, such as genes without counts, are skipped.
+func ReadDETables(logger *slog.Logger, tables []string) FileData {
+	data := FileData{
+		ID:      "expression",
+		Headers: deTableHeaders,
+		Entries: make([][]string, 0),
+	}
+	for _, table := range tables {
+		condition, fileName, ok := strings.Cut(table, "=")
+		if !ok {
+			condition, fileName = "", table
+		}
+		data.Entries = append(data.Entries, readDETable(logger, fileName, condition)...)
+	}
+	logger.Info("Finished reading differential expression tables", "tables", len(tables), "entries", len(data.Entries))
+	return data
+}
+
+// readDETable reads the entries of a single differential expression table
+func readDETable(logger *slog.Logger, fileName, condition string) [][]string {
+	file, err := fileio.OpenDecompressedFile(fileName)
+	if err != nil {
+		logger.Error("Failed to open differential expression table", "error", err, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		logger.Error("Failed to read differential expression table", "error", err, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	firstLine, _, _ := strings.Cut(string(content), "\n")
+	reader := csv.NewReader(strings.NewReader(string(content)))
+	if strings.Count(firstLine, "\t") > strings.Count(firstLine, ",") {
+		reader.Comma = '\t'
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		logger.Error("Failed to parse differential expression table", "error", err, "fileName", fileName)
+		log.Panic("unrecoverable error")
+	}
+	if len(records) < 2 {
+		logger.Warn("Differential expression table has no genes", "fileName", fileName)
+		return nil
+	}
+	// R writes tables with row names and without a header for the row names, unless written as csv
+	header := records[0]
+	if len(header) == len(records[1])-1 {
+		header = append([]string{""}, header...)
+	}
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	geneColumn, ok := firstColumn(columns, deTableGeneColumns...)
+	if !ok {
+		// the row names
+		geneColumn, ok = columns[""]
+	}
+	lfcColumn, hasLFC := firstColumn(columns, deTableLFCColumns...)
+	padjColumn, hasPadj := firstColumn(columns, deTablePadjColumns...)
+	pValueColumn, hasPValue := columns[deTablePValueColumn]
+	if !ok || !hasLFC || !hasPadj || !hasPValue {
+		logger.Error("Not a DESeq2 or edgeR result table, expected a gene, log2FoldChange or logFC, pvalue or PValue, and padj or FDR column", "fileName", fileName, "header", header)
+		log.Panic("unrecoverable error")
+	}
+	statColumn, hasStat := columns[deTableStatColumn]
+	conditionColumn, hasCondition := columns["condition"]
+	if condition == "" && !hasCondition {
+		condition = strings.TrimSuffix(filepath.Base(fileio.TrimCompressionExtension(fileName)), filepath.Ext(fileio.TrimCompressionExtension(fileName)))
+	}
+
+	entries := make([][]string, 0, len(records)-1)
+	skipped := 0
+	for _, record := range records[1:] {
+		if len(record) != len(header) {
+			logger.Error("Incorrect number of columns", "fileName", fileName, "record", record)
+			continue
+		}
+		lfc, err := strconv.ParseFloat(record[lfcColumn], 64)
+		if err != nil || math.IsNaN(lfc) {
+			skipped++
+			continue
+		}
+		entryCondition := condition
+		if entryCondition == "" {
+			entryCondition = record[conditionColumn]
+		}
+		zscore := "NA"
+		if hasStat {
+			zscore = record[statColumn]
+		} else if pValue, err := strconv.ParseFloat(record[pValueColumn], 64); err == nil {
+			zscore = strconv.FormatFloat(pValueZScore(pValue, lfc), 'g', -1, 64)
+		}
+		entries = append(entries, []string{
+			record[geneColumn],
+			entryCondition,
+			record[pValueColumn],
+			record[padjColumn],
+			record[lfcColumn],
+			zscore,
+		})
+	}
+	if skipped > 0 {
+		logger.Info("Skipped genes without a log fold change", "fileName", fileName, "genes", skipped)
+	}
+	return entries
+}
+
+// firstColumn returns the index of the first of the names that is a column
+func firstColumn(columns map[string]int, names ...string) (int, bool) {
+	for _, name := range names {
+		if index, ok := columns[name]; ok {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+// pValueZScore converts a two-sided p-value to the z-score with the sign of the log fold change
+func pValueZScore(pValue, lfc float64) float64 {
+	// p-values of 0 are capped at the smallest p-value that has a finite z-score
+	tail := math.Min(math.Max(pValue/2, math.SmallestNonzeroFloat64), 0.5)
+	zscore := -distuv.UnitNormal.Quantile(tail)
+	if lfc < 0 {
+		return -zscore
+	}
+	return zscore
+}
+
+// CallDifferentialExpression returns the genes of the expression data with an adjusted p-value of at most the padj
+// cutoff and an absolute log fold change of at least the lfc cutoff, with the columns gene name and condition.
+func CallDifferentialExpression(logger *slog.Logger, expressionFileData FileData, padjCutoff, lfcCutoff float64) FileData {
+	data := FileData{
+		ID:      "differential expression",
+		Headers: map[string]int{"gene name": 0, "condition": 1},
+		Entries: make([][]string, 0),
+	}
+	for _, entry := range expressionFileData.Entries {
+		padj, err := strconv.ParseFloat(entry[expressionFileData.Headers["padj"]], 64)
+		if err != nil || padj > padjCutoff {
+			// genes without an adjusted p-value were filtered by the differential expression analysis
+			continue
+		}
+		lfc, err := strconv.ParseFloat(entry[expressionFileData.Headers["lfc"]], 64)
+		if err != nil || math.Abs(lfc) < lfcCutoff {
+			continue
+		}
+		data.Entries = append(data.Entries, []string{
+			entry[expressionFileData.Headers["gene name"]],
+			entry[expressionFileData.Headers["condition"]],
+		})
+	}
+	logger.Info("Called differentially expressed genes",
+		"padjCutoff", padjCutoff,
+		"lfcCutoff", lfcCutoff,
+		"genes", len(data.Entries),
+	)
+	return data
+}
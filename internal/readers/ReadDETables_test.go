@@ -0,0 +1,73 @@
+package readers
+
+import (
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+func TestReadDETables(t *testing.T) {
+	data := ReadDETables(slog.Default(), []string{"testdata/deseq2.csv", "tumor=testdata/edger.tsv"})
+	lines := make([]string, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		lines = append(lines, strings.Join([]string{
+			entry[data.Headers["gene name"]],
+			entry[data.Headers["condition"]],
+			entry[data.Headers["padj"]],
+			entry[data.Headers["lfc"]],
+		}, "\t"))
+	}
+	slices.Sort(lines)
+	// the genes without a log fold change are skipped, the conditions are the file name and the given condition
+	expected := []string{
+		"ATM\tdeseq2\tNA\t1.2",
+		"FOS\tdeseq2\t0.002\t-1.5",
+		"MYC\tdeseq2\t0.03\t-0.5",
+		"MYC\ttumor\t0.2\t3",
+		"TP53\tdeseq2\t1.1e-05\t2.5",
+		"TP53\ttumor\t0.01\t-2",
+	}
+	if !slices.Equal(lines, expected) {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+
+	// the zscore is the Wald statistic of DESeq2, and derived from the p-value and the lfc sign for edgeR
+	zscores := make(map[string]float64)
+	for _, entry := range data.Entries {
+		zscore, err := strconv.ParseFloat(entry[data.Headers["zscore"]], 64)
+		if err != nil {
+			t.Fatalf("failed to parse zscore %s: %v", entry[data.Headers["zscore"]], err)
+		}
+		zscores[entry[data.Headers["gene name"]]+"\t"+entry[data.Headers["condition"]]] = zscore
+	}
+	if zscores["FOS\tdeseq2"] != -3.75 {
+		t.Errorf("expected the DESeq2 statistic -3.75, got %f", zscores["FOS\tdeseq2"])
+	}
+	if math.Abs(zscores["TP53\ttumor"]+2) > 1e-3 {
+		t.Errorf("expected the edgeR zscore -2, got %f", zscores["TP53\ttumor"])
+	}
+
+	// the network weighting uses the same table
+	gim := types.NewGeneIDMap()
+	lfcs := MakeExpressionMap(slog.Default(), gim, data, "lfc")
+	if lfc := lfcs["tumor"][gim.GetIDFromName("TP53")]; lfc != -2 {
+		t.Errorf("expected the lfc -2 of TP53 in tumor, got %f", lfc)
+	}
+
+	// the differentially expressed genes pass both cutoffs
+	de := CallDifferentialExpression(slog.Default(), data, 0.05, 1)
+	called := make([]string, 0, len(de.Entries))
+	for _, entry := range de.Entries {
+		called = append(called, entry[de.Headers["gene name"]]+"\t"+entry[de.Headers["condition"]])
+	}
+	slices.Sort(called)
+	expectedCalled := []string{"FOS\tdeseq2", "TP53\tdeseq2", "TP53\ttumor"}
+	if !slices.Equal(called, expectedCalled) {
+		t.Errorf("expected %q, got %q", expectedCalled, called)
+	}
+}
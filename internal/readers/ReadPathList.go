@@ -4,11 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"log/slog"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/MarchalLab/gonetic/internal/common/compare"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
 	"github.com/MarchalLab/gonetic/internal/common/types"
 )
 
@@ -62,6 +62,7 @@ func filterPaths(
 	return cnfPathsMap
 }
 
+// ReadPathList reads the paths in the given file, the file may be gzip or zstd compressed
 func ReadPathList(
 	logger *slog.Logger,
 	gim *types.GeneIDMap,
@@ -70,8 +71,9 @@ func ReadPathList(
 	cutoff float64,
 	fileName string,
 ) map[types.CNFHeader]types.CompactPathList {
-	// Open the file
-	file, err := os.Open(fileName)
+	// Open the file, which may be compressed
+	fileName = fileio.ResolveCompressedFile(fileName)
+	file, err := fileio.OpenDecompressedFile(fileName)
 	if err != nil {
 		logger.Error("failed to open file", "fileName", fileName, "err", err)
 		return nil
@@ -84,10 +86,16 @@ func ReadPathList(
 	// Read the file line by line
 	scanner := bufio.NewScanner(file)
 	pathIDs := make(map[int]struct{})
-	for pathID := 0; scanner.Scan(); pathID++ {
+	pathID := 0
+	for scanner.Scan() {
 		line := scanner.Text()
-		processLine(line, logger, gim, pathID, pathIDs, cnfs, cutoff)
+		if isHeaderLine(line) {
+			// the header does not contain paths, and does not count for the path IDs
+			continue
+		}
 		// Dispatch each line to a helper function
+		processLine(line, logger, gim, pathID, pathIDs, cnfs, cutoff)
+		pathID++
 	}
 	if err := scanner.Err(); err != nil {
 		logger.Error("error reading file", "fileName", fileName, "err", err)
@@ -152,3 +152,32 @@ func writePaths(gim *types.GeneIDMap, fileName string, paths map[types.CNFHeader
 	}
 	fw.WriteLinesToNewFile(fileName, lines)
 }
+
+func TestReadCompressedPathList(t *testing.T) {
+	logger := slog.Default()
+	gim := ReadIDMap[types.GeneID, types.GeneName]("testdata/gene-ids")
+	inputFile := filepath.Join("testdata", "mutation.paths")
+	outputDir := "testresult/ReadCompressedPathList"
+	expected := ReadPathList(logger, gim, math.MaxInt, "test", 0.0, inputFile)
+	lines := fileio.ReadListFromFile(inputFile, false)
+	fw := fileio.FileWriter{Logger: logger}
+	for _, compression := range []string{fileio.GzipCompression, fileio.ZstdCompression} {
+		t.Run(compression, func(t *testing.T) {
+			fileio.CreateEmptyDir(outputDir)
+			compressedFile := fileio.CompressedFileName(filepath.Join(outputDir, "mutation.paths"), compression)
+			if err := fw.AppendLinesToCompressedFile(compressedFile, compression, lines); err != nil {
+				t.Fatalf("failed to write compressed paths: %v", err)
+			}
+			// the compressed file is found from the uncompressed name
+			paths := ReadPathList(logger, gim, math.MaxInt, "test", 0.0, filepath.Join(outputDir, "mutation.paths"))
+			if len(paths) != len(expected) {
+				t.Fatalf("expected %d headers, got %d", len(expected), len(paths))
+			}
+			for header, expectedPaths := range expected {
+				if len(paths[header]) != len(expectedPaths) {
+					t.Errorf("expected %d paths for %v, got %d", len(expectedPaths), header, len(paths[header]))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package readers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+)
+
+// ReadRemovedGenes reads the genes that were removed from the network, together with the reason of their removal
+func ReadRemovedGenes(filename string) map[types.GeneName]string {
+	removed := make(map[types.GeneName]string)
+	// no genes were removed if the file does not exist
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		return removed
+	}
+	for _, entry := range fileio.ReadListFromFile(filename, true) {
+		if len(entry) == 0 || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		split := strings.Split(entry, "\t")
+		if len(split) != 2 {
+			panic(fmt.Sprintf("removed gene entries should have two columns: %s", entry))
+		}
+		removed[types.GeneName(split[0])] = split[1]
+	}
+	return removed
+}
@@ -27,7 +27,7 @@ func (nlp networkLineParser) tokenizeLine(line string) []string {
 	}
 	for _, sep := range []string{",", ";", "\t", " "} {
 		vals = strings.Split(line, sep)
-		if len(vals) >= 2 && len(vals) <= 6 {
+		if len(vals) >= 2 && len(vals) <= 7 {
 			return vals
 		}
 	}
@@ -121,6 +121,7 @@ type parsedInteraction struct {
 	direction   string
 	probability float64
 	rawTypeID   string
+	sign        types.InteractionSign
 }
 
 // parseDataLine parses a data line and returns the parsed interaction information
@@ -156,8 +157,14 @@ func (nlp networkLineParser) parseDataLine(
 		prob = nlp.parseProbability(tokens[4])
 	}
 
-	if len(tokens) > 5 {
-		counter = nlp.parseInteractionID(tokens[5], counter)
+	// the optional sign column follows the probability, possibly after an interaction ID
+	sign := types.Unsigned
+	for _, token := range tokens[min(len(tokens), 5):] {
+		if parsed, ok := types.ParseInteractionSign(token); ok {
+			sign = parsed
+			continue
+		}
+		counter = nlp.parseInteractionID(token, counter)
 	}
 
 	return parsedInteraction{
@@ -167,5 +174,6 @@ func (nlp networkLineParser) parseDataLine(
 		direction:   direction,
 		probability: prob,
 		rawTypeID:   typ,
+		sign:        sign,
 	}, counter + 1, true
 }
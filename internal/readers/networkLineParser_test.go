@@ -206,6 +206,7 @@ func TestParseDataLine(t *testing.T) {
 		expectTyp  string
 		expectDir  string
 		expectProb float64
+		expectSign types.InteractionSign
 	}{
 		{
 			name:       "basic 2-token line",
@@ -223,6 +224,24 @@ func TestParseDataLine(t *testing.T) {
 			expectDir:  "directed",
 			expectProb: 0.9,
 		},
+		{
+			name:       "signed line",
+			line:       "A,B,pd,directed,0.9,inhibition",
+			expectOK:   true,
+			expectTyp:  "pd",
+			expectDir:  "directed",
+			expectProb: 0.9,
+			expectSign: types.Inhibition,
+		},
+		{
+			name:       "signed line with interaction id",
+			line:       "A\tB\tpd\tdirected\t0.9\t42\t+",
+			expectOK:   true,
+			expectTyp:  "pd",
+			expectDir:  "directed",
+			expectProb: 0.9,
+			expectSign: types.Activation,
+		},
 		{
 			name:       "undirected line",
 			line:       "X,Y,pp,undirected,0.7",
@@ -275,6 +294,9 @@ func TestParseDataLine(t *testing.T) {
 			if intx.probability != tt.expectProb {
 				t.Errorf("expected prob %.2f, got %.2f", tt.expectProb, intx.probability)
 			}
+			if intx.sign != tt.expectSign {
+				t.Errorf("expected sign %s, got %s", tt.expectSign, intx.sign)
+			}
 		})
 	}
 }
@@ -2,48 +2,63 @@ package run
 
 import (
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
-	"github.com/MarchalLab/gonetic/internal/pathfinding"
 	"github.com/MarchalLab/gonetic/internal/readers"
 )
 
 // EQTL is the entry point for the EQTL setting
 func EQTL(args *arguments.EQTL) {
-	args.PathTypes = make([]string, 0, 3)
+	args.PathTypes = make([]string, 0, 4)
 	args.PathTypes = append(args.PathTypes, "eqtl")
 	if args.WithMutation {
 		args.PathTypes = append(args.PathTypes, "mutation")
 	}
+	if args.CNADataFile != "" {
+		args.PathTypes = append(args.PathTypes, "cna")
+	}
 	if args.WithExpression {
 		args.PathTypes = append(args.PathTypes, "expression")
 	}
 
+	checkSelectionWeights(args.Common)
+
 	// Load the relevant data
 	readers.ReadIndexes(args.Common)
-	mutationFileData := readers.ReadInputDataHeadersMutationFile(args.Logger, args.MutationDataFile)
-	expressionFileData := readers.ReadExpressionFile(args.Logger, args.ExpressionFile, args.ExpressionWeightingMethod)
-	differentialExpressionFileData := readers.ReadDifferentialExpressionFile(args.Logger, args.DifferentialExpressionList)
+	readers.CheckPathFiles(args.Common, args.QTLSpecific, args)
+	mutationFileData := readers.ReadMutationFile(
+		args.Logger,
+		args.MutationDataFile,
+		args.MutationFormat,
+		readers.NewVariantFilter(args.VariantClassifications, args.ExcludedVariantClassifications),
+	)
+	expressionFileData, differentialExpressionFileData := readers.ReadExpressionData(args.Expression)
+	cnaFileData := readers.ReadCNAFile(args.Logger, args.CNADataFile)
 
 	// Run different parts of the program
-	if !args.SkipPathFinding {
-		pathfinding.Run(
-			mutationFileData,
-			expressionFileData,
-			differentialExpressionFileData,
-			args.Expression,
-			args.QTLSpecific,
-			args.Common,
-			args,
-		)
-	}
-	if !args.SkipOptimization {
-		optimizer := NewOptimizationRunner(args.Common, false)
-		optimizer.Run()
-	}
-	if !args.SkipInterpreter {
-		interpreter := NewInterpretation(args.Common)
-		interpreter.Run(
-			mutationFileData,
-			differentialExpressionFileData,
-		)
-	}
+	setting{
+		Common:         args.Common,
+		startIsMutated: false,
+		data: inputData{
+			mutation:               mutationFileData,
+			cna:                    cnaFileData,
+			expression:             expressionFileData,
+			differentialExpression: differentialExpressionFileData,
+		},
+		settingArgs: func(common *arguments.Common) (*arguments.Expression, *arguments.QTLSpecific, *arguments.EQTL) {
+			expressionArgs := *args.Expression
+			expressionArgs.Common = common
+			eqtlArgs := *args
+			eqtlArgs.Expression = &expressionArgs
+			return &expressionArgs, args.QTLSpecific, &eqtlArgs
+		},
+		rerun: func(nullArgs *arguments.Common) {
+			expressionArgs := *args.Expression
+			expressionArgs.Common = nullArgs
+			qtlArgs := *args.QTLSpecific
+			qtlArgs.Permutations = 0
+			eqtlArgs := *args
+			eqtlArgs.Expression = &expressionArgs
+			eqtlArgs.QTLSpecific = &qtlArgs
+			EQTL(&eqtlArgs)
+		},
+	}.run()
 }
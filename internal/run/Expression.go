@@ -2,38 +2,33 @@ package run
 
 import (
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
-	"github.com/MarchalLab/gonetic/internal/pathfinding"
 	"github.com/MarchalLab/gonetic/internal/readers"
 )
 
 // Expression is the entry point for the expression setting
 func Expression(args *arguments.Expression) {
 	args.PathTypes = []string{"expression"}
+	checkSelectionWeights(args.Common)
+
 	// Load the relevant data
 	readers.ReadIndexes(args.Common)
-	expressionFileData := readers.ReadExpressionFile(args.Logger, args.ExpressionFile, args.ExpressionWeightingMethod)
-	differentialExpressionFileData := readers.ReadDifferentialExpressionFile(args.Logger, args.DifferentialExpressionList)
+	readers.CheckPathFiles(args.Common, nil, nil)
+	expressionFileData, differentialExpressionFileData := readers.ReadExpressionData(args)
 
 	// Run different parts of the program
-	if !args.SkipPathFinding {
-		pathfinding.Run(
-			readers.FileData{},
-			expressionFileData,
-			differentialExpressionFileData,
-			args,
-			&arguments.QTLSpecific{},
-			args.Common,
-			&arguments.EQTL{},
-		)
-	}
-	if !args.SkipOptimization {
-		optimizer := NewOptimizationRunner(args.Common, false)
-		optimizer.Run()
-	}
-	if !args.SkipInterpreter {
-		interpreter := NewInterpretation(args.Common)
-		interpreter.Run(
-			differentialExpressionFileData,
-		)
-	}
+	setting{
+		Common:         args.Common,
+		startIsMutated: false,
+		data:           inputData{expression: expressionFileData, differentialExpression: differentialExpressionFileData},
+		settingArgs: func(common *arguments.Common) (*arguments.Expression, *arguments.QTLSpecific, *arguments.EQTL) {
+			expressionArgs := *args
+			expressionArgs.Common = common
+			return &expressionArgs, &arguments.QTLSpecific{}, &arguments.EQTL{}
+		},
+		rerun: func(nullArgs *arguments.Common) {
+			expressionArgs := *args
+			expressionArgs.Common = nullArgs
+			Expression(&expressionArgs)
+		},
+	}.run()
 }
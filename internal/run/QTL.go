@@ -2,39 +2,41 @@ package run
 
 import (
 	"github.com/MarchalLab/gonetic/internal/common/arguments"
-	"github.com/MarchalLab/gonetic/internal/pathfinding"
 	"github.com/MarchalLab/gonetic/internal/readers"
 )
 
 // QTL is the entry point for the QTL setting
 func QTL(args *arguments.QTL) {
 	args.PathTypes = []string{"mutation"}
+	if args.CNADataFile != "" {
+		args.PathTypes = append(args.PathTypes, "cna")
+	}
+
+	checkSelectionWeights(args.Common)
 
 	// Load the relevant data
 	readers.ReadIndexes(args.Common)
-	mutationFileData := readers.ReadInputDataHeadersMutationFile(args.Logger, args.MutationDataFile)
+	readers.CheckPathFiles(args.Common, args.QTLSpecific, nil)
+	mutationFileData := readers.ReadMutationFile(
+		args.Logger,
+		args.MutationDataFile,
+		args.MutationFormat,
+		readers.NewVariantFilter(args.VariantClassifications, args.ExcludedVariantClassifications),
+	)
+	cnaFileData := readers.ReadCNAFile(args.Logger, args.CNADataFile)
 
 	// Run different parts of the program
-	if !args.SkipPathFinding {
-		pathfinding.Run(
-			mutationFileData,
-			readers.FileData{},
-			readers.FileData{},
-			&arguments.Expression{},
-			args.QTLSpecific,
-			args.Common,
-			&arguments.EQTL{},
-		)
-	}
-	if !args.SkipOptimization {
-		optimizer := NewOptimizationRunner(args.Common, true)
-		optimizer.Run()
-	}
-	if !args.SkipInterpreter {
-		// run the interpreter
-		interpreter := NewInterpretation(args.Common)
-		interpreter.Run(
-			mutationFileData,
-		)
-	}
+	setting{
+		Common:         args.Common,
+		startIsMutated: true,
+		data:           inputData{mutation: mutationFileData, cna: cnaFileData},
+		settingArgs: func(common *arguments.Common) (*arguments.Expression, *arguments.QTLSpecific, *arguments.EQTL) {
+			return &arguments.Expression{}, args.QTLSpecific, &arguments.EQTL{}
+		},
+		rerun: func(nullArgs *arguments.Common) {
+			qtlArgs := *args.QTLSpecific
+			qtlArgs.Permutations = 0
+			QTL(&arguments.QTL{Common: nullArgs, QTLSpecific: &qtlArgs})
+		},
+	}.run()
 }
@@ -0,0 +1,131 @@
+package run
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/interpretation"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+const bootstrapDirectoryName = "bootstrap"
+
+// bootstrapPipeline runs the path finding and the optimization on the resampled input data, and returns the resampled
+// genes of interest
+type bootstrapPipeline func(args *arguments.Common, resampled []readers.FileData) []readers.FileData
+
+// selectionCounts counts the bootstrap replicates that select a gene or an interaction, per score type
+type selectionCounts[ID comparable] map[string]map[ID]int
+
+func (counts selectionCounts[ID]) add(scoreType string, id ID) {
+	if _, ok := counts[scoreType]; !ok {
+		counts[scoreType] = make(map[ID]int)
+	}
+	counts[scoreType][id]++
+}
+
+// bootstrap reruns the path finding and the optimization on bootstrap replicates of the input data, in which the
+// conditions are resampled with replacement. The selection frequency of the genes and the interactions of the
+// resulting networks over the replicates measures how robust the resulting networks are to the choice of conditions.
+func (runner interpretationRunner) bootstrap(run bootstrapPipeline, fileData []readers.FileData, genesOfInterestData ...readers.FileData) {
+	runner.Info("Running bootstrap", "replicates", runner.Bootstrap)
+	directory := filepath.Join(runner.OutputFolder, bootstrapDirectoryName)
+	fileio.CreateEmptyDir(directory)
+
+	geneCounts := make(selectionCounts[types.GeneID])
+	interactionCounts := make(selectionCounts[types.InteractionID])
+	for replicate := range runner.Bootstrap {
+		bootstrapArgs := rerunArgs(runner.Common, filepath.Join(directory, strconv.Itoa(replicate)))
+		runner.Info("Bootstrap replicate", "replicate", replicate, "outputFolder", bootstrapArgs.OutputFolder)
+
+		resampledGenesOfInterest := run(bootstrapArgs, readers.BootstrapConditions(fileData...))
+		replicateRunner := NewInterpretation(bootstrapArgs)
+		orderedConditions := replicateRunner.orderConditions(interpretation.NewGenesOfInterestMap(bootstrapArgs.GeneIDMap, resampledGenesOfInterest))
+		for _, ranked := range replicateRunner.rankAll(orderedConditions) {
+			for gene := range ranked.network.Genes() {
+				geneCounts.add(ranked.scoreType, gene)
+			}
+			for interaction := range ranked.network.Interactions() {
+				interactionCounts.add(ranked.scoreType, interaction)
+			}
+		}
+	}
+
+	// annotate the resulting networks with the selection frequency of their interactions
+	geneNameMap := readers.ConvertMappingFile(runner.Logger, runner.MappingFile)
+	orderedConditions := runner.orderConditions(interpretation.NewGenesOfInterestMap(runner.GeneIDMap, genesOfInterestData))
+	observedGenes := make(map[string]types.GeneSet)
+	observedInteractions := make(map[string]types.InteractionIDSet)
+	for _, ranked := range runner.rankAll(orderedConditions) {
+		observedGenes[ranked.scoreType] = ranked.network.Genes()
+		observedInteractions[ranked.scoreType] = ranked.network.Interactions()
+		stability := make(map[types.InteractionID]float64, len(ranked.edgesInCondition))
+		for interaction := range ranked.edgesInCondition {
+			stability[interaction] = runner.selectionFrequency(interactionCounts[ranked.scoreType][interaction])
+		}
+		resultsDirectory := runner.ResultsDirectory(ranked.scoreType)
+		fileio.CreateDirKeepContent(resultsDirectory)
+		err := runner.WriteStabilitySubnetwork(
+			runner.StabilityNetworkFile(resultsDirectory),
+			ranked.edgesInCondition,
+			stability,
+			geneNameMap,
+		)
+		if err != nil {
+			runner.Error("error writing stability subnetwork", "err", err)
+		}
+	}
+
+	// summarize the selection frequencies of all genes and interactions that are selected in any replicate
+	geneLines := make([]string, 0)
+	for scoreType, counts := range geneCounts {
+		for gene, count := range counts {
+			_, observed := observedGenes[scoreType][gene]
+			geneLines = append(geneLines, fmt.Sprintf(
+				"%s\t%s\t%d\t%f\t%t",
+				scoreType,
+				runner.GetMappedName(gene, geneNameMap),
+				count,
+				runner.selectionFrequency(count),
+				observed,
+			))
+		}
+	}
+	interactionLines := make([]string, 0)
+	for scoreType, counts := range interactionCounts {
+		for interaction, count := range counts {
+			interactionLines = append(interactionLines, fmt.Sprintf(
+				"%s\t%s\t%s\t%s\t%d\t%f\t%t",
+				scoreType,
+				runner.GetMappedName(interaction.From(), geneNameMap),
+				runner.GetMappedName(interaction.To(), geneNameMap),
+				runner.InteractionStore.InteractionType(interaction),
+				count,
+				runner.selectionFrequency(count),
+				observedInteractions[scoreType].Has(interaction),
+			))
+		}
+	}
+	slices.Sort(geneLines)
+	slices.Sort(interactionLines)
+	fileName := filepath.Join(directory, "geneStability.txt")
+	err := runner.WriteLinesToNewFile(fileName, []string{"#scoreType\tgene\tselected\tfrequency\tobserved"}, geneLines)
+	if err != nil {
+		runner.Error("error writing gene stability", "err", err, "file", fileName)
+	}
+	fileName = filepath.Join(directory, "interactionStability.txt")
+	err = runner.WriteLinesToNewFile(fileName, []string{"#scoreType\tfrom\tto\ttype\tselected\tfrequency\tobserved"}, interactionLines)
+	if err != nil {
+		runner.Error("error writing interaction stability", "err", err, "file", fileName)
+	}
+}
+
+// selectionFrequency is the fraction of bootstrap replicates that select a gene or an interaction
+func (runner interpretationRunner) selectionFrequency(count int) float64 {
+	return float64(count) / float64(runner.Bootstrap)
+}
@@ -1,6 +1,7 @@
 package run
 
 import (
+	"path/filepath"
 	"sort"
 
 	"github.com/MarchalLab/gonetic/internal/graph"
@@ -16,6 +17,8 @@ type interpretationRunner struct {
 	*arguments.Common
 	*interpretation.Interpreter
 	nwr *readers.NetworkReader
+	// the condition-specific rankings of permuted data per score type, used for empirical p-values
+	null map[string][]map[string]map[types.Condition]map[types.GeneID]float64
 }
 
 func NewInterpretation(args *arguments.Common) interpretationRunner {
@@ -42,6 +45,35 @@ func (runner interpretationRunner) Run(genesOfInterestData ...readers.FileData)
 	// create an ordered list of condition names; the ordering is arbitrary, but fixed for indexing.
 	orderedConditions := runner.orderConditions(genesOfInterest)
 
+	// rank the genes for each score type and write the results
+	for _, ranked := range runner.rankAll(orderedConditions) {
+		var pValues map[string]map[types.Condition]map[types.GeneID]float64
+		if null, ok := runner.null[ranked.scoreType]; ok {
+			pValues = interpretation.EmpiricalPValues(ranked.ranking, null)
+		}
+		runner.writeResults(
+			ranked.scoreType,
+			geneNameMap,
+			ranked.ranking,
+			pValues,
+			orderedConditions,
+			genesOfInterest,
+			ranked.edgesInCondition,
+			ranked.network.Interactions(),
+		)
+	}
+}
+
+// rankedScoreType is the network selected for a score type and the condition-specific ranking of its genes
+type rankedScoreType struct {
+	scoreType        string
+	network          *graph.Network
+	edgesInCondition map[types.InteractionID][]float64
+	ranking          map[string]map[types.Condition]map[types.GeneID]float64
+}
+
+// rankAll selects a network and ranks its genes for each score type
+func (runner interpretationRunner) rankAll(orderedConditions types.Conditions) []rankedScoreType {
 	// get the score offset and the score indices for the different score types
 	scoreOffset, pathTypeScoreIdxs := runner.offsetAndScoreIdxs()
 
@@ -50,63 +82,72 @@ func (runner interpretationRunner) Run(genesOfInterestData ...readers.FileData)
 	// Calculate the rank of each network for each objective
 	rankMaps, topScores := createAllRankMaps(networks)
 
-	// run the interpretation for each score type
-	if runner.OptimizeSampleCount {
-		runner.runPerScoreType(
-			networks,
-			rankMaps,
-			topScores,
-			"sample-rank",
-			genesOfInterest,
-			geneNameMap,
-			orderedConditions,
-			scoreSelector(scoreOffset-1),
-			invRankSelector(pathTypeScoreIdxs),
-		)
-		runner.runPerScoreType(
+	ranked := make([]rankedScoreType, 0)
+	rank := func(scoreType string, scoreSummarizers ...scoreSummarizer) {
+		ranked = append(ranked, runner.rankPerScoreType(
 			networks,
 			rankMaps,
 			topScores,
-			"sample-norm",
-			genesOfInterest,
-			geneNameMap,
+			scoreType,
 			orderedConditions,
-			scoreSelector(scoreOffset-1),
-			normalizedScoreSelector(pathTypeScoreIdxs),
-		)
+			scoreSummarizers...,
+		))
 	}
-	runner.runPerScoreType(
-		networks,
-		rankMaps,
-		topScores,
-		"ranksum",
-		genesOfInterest,
-		geneNameMap,
-		orderedConditions,
-		invRankSelector(pathTypeScoreIdxs),
-	)
-	runner.runPerScoreType(
-		networks,
-		rankMaps,
-		topScores,
-		"normsum",
-		genesOfInterest,
-		geneNameMap,
-		orderedConditions,
-		normalizedScoreSelector(pathTypeScoreIdxs),
-	)
+	// run the interpretation for each score type
+	if runner.OptimizeSampleCount {
+		rank("sample-rank", scoreSelector(scoreOffset-1), invRankSelector(pathTypeScoreIdxs))
+		rank("sample-norm", scoreSelector(scoreOffset-1), normalizedScoreSelector(pathTypeScoreIdxs))
+	}
+	rank("ranksum", invRankSelector(pathTypeScoreIdxs))
+	rank("normsum", normalizedScoreSelector(pathTypeScoreIdxs))
 	for pathTypeIdx, pathType := range runner.PathTypes {
-		scoreIdx := scoreOffset + pathTypeIdx
-		runner.runPerScoreType(
-			networks,
-			rankMaps,
-			topScores,
-			pathType,
-			genesOfInterest,
-			geneNameMap,
-			orderedConditions,
-			scoreSelector(scoreIdx),
-		)
+		rank(pathType, scoreSelector(scoreOffset+pathTypeIdx))
+	}
+	// run the interpretation for each requested selection strategy
+	for _, strategy := range runner.Selection {
+		selector, ok := runner.frontSelector(strategy, len(topScores))
+		if !ok {
+			continue
+		}
+		rank(strategy, selectionStrategy(networks, selector), normalizedScoreSelector(pathTypeScoreIdxs))
+	}
+	return ranked
+}
+
+// checkSelectionWeights panics if the selection weights do not match the objectives of the path types
+func checkSelectionWeights(args *arguments.Common) {
+	if err := args.CheckSelectionWeights(); err != nil {
+		args.Error("Invalid selection weights", "err", err, "pathTypes", args.PathTypes)
+		panic("Cannot select networks")
+	}
+}
+
+// frontSelector returns the front selector for the given selection strategy
+func (runner interpretationRunner) frontSelector(strategy string, objectiveCount int) (frontSelector, bool) {
+	switch strategy {
+	case kneeSelection:
+		return kneeSelector, true
+	case weightedSumSelection:
+		weights := runner.SelectionWeights
+		if len(weights) == 0 {
+			weights = make([]float64, objectiveCount)
+			for i := range weights {
+				weights[i] = 1
+			}
+		}
+		if len(weights) != objectiveCount {
+			runner.Error("the number of selection weights does not match the number of objectives",
+				"weights", weights,
+				"objectives", objectiveCount,
+			)
+			return nil, false
+		}
+		return weightedSumSelector(weights), true
+	case hvContributionSelection:
+		return hvContributionSelector, true
+	default:
+		runner.Error("unknown selection strategy", "strategy", strategy)
+		return nil, false
 	}
 }
 
@@ -189,16 +230,14 @@ func compareNetworksByScore(
 	}
 }
 
-func (runner interpretationRunner) runPerScoreType(
+func (runner interpretationRunner) rankPerScoreType(
 	networks []*graph.Network,
 	rankMaps []map[float64]int,
 	topScores []float64,
 	scoreType string,
-	genesOfInterest map[string]interpretation.GenesOfInterest,
-	geneNameMap types.GeneTranslationMap,
 	orderedConditions types.Conditions,
 	scoreSummarizers ...scoreSummarizer,
-) {
+) rankedScoreType {
 	// Use all remaining highest scoring subnetworks to produce a ranking of edges
 	// based on the highest edge cost for which the edge was found.
 	network := runner.selectNetwork(networks, rankMaps, topScores, scoreSummarizers...)
@@ -220,26 +259,22 @@ func (runner interpretationRunner) runPerScoreType(
 	)
 
 	// create condition specific ranking of genes of interest
-	conditionSpecificGeneRanking := interpretation.ConditionSpecificRanking(
-		orderedConditions,
-		pathsInCondition,
-	)
-
-	runner.writeResults(
-		scoreType,
-		geneNameMap,
-		conditionSpecificGeneRanking,
-		orderedConditions,
-		genesOfInterest,
-		edgesInCondition,
-		network.Interactions(),
-	)
+	return rankedScoreType{
+		scoreType:        scoreType,
+		network:          network,
+		edgesInCondition: edgesInCondition,
+		ranking: interpretation.ConditionSpecificRanking(
+			orderedConditions,
+			pathsInCondition,
+		),
+	}
 }
 
 func (runner interpretationRunner) writeResults(
 	scoreType string,
 	geneNameMap types.GeneTranslationMap,
 	conditionSpecificGeneRanking map[string]map[types.Condition]map[types.GeneID]float64,
+	pValues map[string]map[types.Condition]map[types.GeneID]float64,
 	orderedConditions types.Conditions,
 	genesOfInterest map[string]interpretation.GenesOfInterest,
 	edgesInCondition map[types.InteractionID][]float64,
@@ -268,6 +303,7 @@ func (runner interpretationRunner) writeResults(
 	err = runner.WriteConditionSpecificRanking(
 		runner.FileWriter,
 		conditionSpecificGeneRanking,
+		pValues,
 		orderedConditions,
 		resultsDirectory,
 		geneNameMap,
@@ -275,6 +311,18 @@ func (runner interpretationRunner) writeResults(
 	if err != nil {
 		runner.Error("error writing condition specific ranking", "err", err)
 	}
+	// report the genes of interest that were removed from the network as unreachable
+	if len(runner.BannedGenes) > 0 || runner.MaxDegree > 0 {
+		err = runner.WriteUnreachableGenes(
+			filepath.Join(resultsDirectory, "unreachable.txt"),
+			readers.ReadRemovedGenes(runner.RemovedGenesFile()),
+			genesOfInterest,
+			geneNameMap,
+		)
+		if err != nil {
+			runner.Error("error writing unreachable genes", "err", err)
+		}
+	}
 	// TODO: write a sif file for the resulting subnetwork
 	// TODO: write XGMML file for resulting subnetwork
 	// write HTML visualization for resulting subnetwork
@@ -0,0 +1,114 @@
+package run
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/interpretation"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+const leaveOneOutDirectoryName = "leave-one-out"
+
+// leaveOneOutPipeline finds the paths of the input data without the dropped condition and reoptimizes, reusing the
+// paths and the d-DNNFs of the full data where possible. It returns the remaining genes of interest.
+type leaveOneOutPipeline func(args *arguments.Common, dropped types.Condition, remaining []readers.FileData) []readers.FileData
+
+// leaveOneOutOverlap is the overlap of a resulting network without a condition with the resulting network of the full data
+type leaveOneOutOverlap struct {
+	scoreType          string
+	condition          types.Condition
+	interactionJaccard float64
+	geneJaccard        float64
+}
+
+// leaveOneOut drops each condition in turn, and compares the resulting networks with those of the full data. A
+// condition is influential when the resulting network depends strongly on it.
+func (runner interpretationRunner) leaveOneOut(run leaveOneOutPipeline, fileData []readers.FileData, genesOfInterestData ...readers.FileData) {
+	orderedConditions := runner.orderConditions(interpretation.NewGenesOfInterestMap(runner.GeneIDMap, genesOfInterestData))
+	runner.Info("Running leave-one-out", "conditions", len(orderedConditions))
+	directory := filepath.Join(runner.OutputFolder, leaveOneOutDirectoryName)
+	fileio.CreateEmptyDir(directory)
+
+	// the resulting networks of the full data
+	fullGenes := make(map[string]types.GeneSet)
+	fullInteractions := make(map[string]types.InteractionIDSet)
+	for _, ranked := range runner.rankAll(orderedConditions) {
+		fullGenes[ranked.scoreType] = ranked.network.Genes()
+		fullInteractions[ranked.scoreType] = ranked.network.Interactions()
+	}
+
+	overlaps := make([]leaveOneOutOverlap, 0)
+	for _, dropped := range orderedConditions {
+		// the files of the full data are only reused through the pipeline
+		droppedArgs := rerunArgs(runner.Common, filepath.Join(directory, string(dropped)))
+		runner.Info("Leave-one-out", "dropped", dropped, "outputFolder", droppedArgs.OutputFolder)
+
+		remainingGenesOfInterest := run(droppedArgs, dropped, readers.DropCondition(string(dropped), fileData...))
+		droppedRunner := NewInterpretation(droppedArgs)
+		remainingConditions := droppedRunner.orderConditions(interpretation.NewGenesOfInterestMap(droppedArgs.GeneIDMap, remainingGenesOfInterest))
+		for _, ranked := range droppedRunner.rankAll(remainingConditions) {
+			overlaps = append(overlaps, leaveOneOutOverlap{
+				scoreType:          ranked.scoreType,
+				condition:          dropped,
+				interactionJaccard: jaccard(fullInteractions[ranked.scoreType], ranked.network.Interactions()),
+				geneJaccard:        jaccard(fullGenes[ranked.scoreType], ranked.network.Genes()),
+			})
+		}
+	}
+
+	// report the overlaps, the most influential conditions first
+	slices.SortFunc(overlaps, func(a, b leaveOneOutOverlap) int {
+		if a.scoreType != b.scoreType {
+			return cmp.Compare(a.scoreType, b.scoreType)
+		}
+		if a.interactionJaccard != b.interactionJaccard {
+			return cmp.Compare(a.interactionJaccard, b.interactionJaccard)
+		}
+		return cmp.Compare(a.condition, b.condition)
+	})
+	lines := make([]string, 0, len(overlaps))
+	influentialLines := make([]string, 0)
+	for _, overlap := range overlaps {
+		influential := overlap.interactionJaccard < runner.LeaveOneOutCutoff
+		lines = append(lines, fmt.Sprintf("%s\t%s\t%f\t%f\t%t", overlap.scoreType, overlap.condition, overlap.interactionJaccard, overlap.geneJaccard, influential))
+		if influential {
+			influentialLines = append(influentialLines, fmt.Sprintf("%s\t%s\t%f", overlap.scoreType, overlap.condition, overlap.interactionJaccard))
+			runner.Warn("Influential condition",
+				"scoreType", overlap.scoreType,
+				"condition", overlap.condition,
+				"interactionJaccard", overlap.interactionJaccard,
+				"geneJaccard", overlap.geneJaccard,
+			)
+		}
+	}
+	fileName := filepath.Join(directory, "leaveOneOut.txt")
+	err := runner.WriteLinesToNewFile(fileName, []string{"#scoreType\tdropped\tinteractionJaccard\tgeneJaccard\tinfluential"}, lines)
+	if err != nil {
+		runner.Error("error writing leave-one-out overlaps", "err", err, "file", fileName)
+	}
+	fileName = filepath.Join(directory, "influentialConditions.txt")
+	err = runner.WriteLinesToNewFile(fileName, []string{"#scoreType\tdropped\tinteractionJaccard"}, influentialLines)
+	if err != nil {
+		runner.Error("error writing influential conditions", "err", err, "file", fileName)
+	}
+}
+
+// jaccard is the size of the intersection of two sets divided by the size of their union
+func jaccard[ID comparable](a, b map[ID]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	intersection := 0
+	for id := range a {
+		if _, ok := b[id]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
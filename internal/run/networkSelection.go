@@ -0,0 +1,177 @@
+package run
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/graph"
+	"github.com/MarchalLab/gonetic/internal/wfg"
+)
+
+// names of the selection strategies that can be requested with --selection
+const (
+	kneeSelection           = arguments.KneeSelection
+	weightedSumSelection    = arguments.WeightedSumSelection
+	hvContributionSelection = arguments.HVContributionSelection
+)
+
+// frontSelector precomputes a summary score for every network on the front, networks that are not on the front score -Inf
+type frontSelector func(front []*graph.Network) map[*graph.Network]float64
+
+// selectionStrategy converts a frontSelector into a scoreSummarizer for the given networks
+func selectionStrategy(networks []*graph.Network, selector frontSelector) scoreSummarizer {
+	front := nonDominatedNetworks(networks)
+	summary := selector(front)
+	return func(network *graph.Network, _ []map[float64]int, _ []float64) float64 {
+		if score, ok := summary[network]; ok {
+			return score
+		}
+		return math.Inf(-1)
+	}
+}
+
+// nonDominatedNetworks returns the networks that are not dominated by any other network, all objectives are maximized
+func nonDominatedNetworks(networks []*graph.Network) []*graph.Network {
+	front := make([]*graph.Network, 0, len(networks))
+	for i, network := range networks {
+		dominated := false
+		for j, other := range networks {
+			if i != j && dominates(other.Scores(), network.Scores()) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, network)
+		}
+	}
+	return front
+}
+
+// dominates returns whether p is at least as good as q in every objective, and better in at least one
+func dominates(p, q []float64) bool {
+	better := false
+	for i := range p {
+		if p[i] < q[i] {
+			return false
+		}
+		if p[i] > q[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// normalizedFront scales every objective of the front to [0, 1]
+// objectives without spread on the front are set to 1
+func normalizedFront(front []*graph.Network) wfg.Front {
+	if len(front) == 0 {
+		return wfg.Front{}
+	}
+	objectiveCount := len(front[0].Scores())
+	lower := make([]float64, objectiveCount)
+	upper := make([]float64, objectiveCount)
+	for i := range objectiveCount {
+		lower[i] = math.Inf(1)
+		upper[i] = math.Inf(-1)
+		for _, network := range front {
+			lower[i] = min(lower[i], network.Scores()[i])
+			upper[i] = max(upper[i], network.Scores()[i])
+		}
+	}
+	points := make(wfg.Front, len(front))
+	for j, network := range front {
+		points[j] = make(wfg.Point, objectiveCount)
+		for i, score := range network.Scores() {
+			if upper[i] == lower[i] {
+				points[j][i] = 1
+				continue
+			}
+			points[j][i] = (score - lower[i]) / (upper[i] - lower[i])
+		}
+	}
+	return points
+}
+
+// kneeSelector scores each network by its distance beyond the hyperplane through the extreme points of the normalized front.
+// The network furthest beyond that hyperplane is the knee point: moving away from it in any direction costs a lot in some objective.
+func kneeSelector(front []*graph.Network) map[*graph.Network]float64 {
+	points := normalizedFront(front)
+	summary := make(map[*graph.Network]float64, len(front))
+	if len(points) == 0 {
+		return summary
+	}
+	normal := extremePointHyperplane(points)
+	norm := mat.Norm(normal, 2)
+	for j, network := range front {
+		summary[network] = (mat.Dot(normal, mat.NewVecDense(len(points[j]), points[j])) - 1) / norm
+	}
+	return summary
+}
+
+// extremePointHyperplane computes the normal w of the hyperplane w.x = 1 through the extreme points of the front,
+// i.e. the points with the best score for each objective.
+// If the extreme points do not define a unique hyperplane, the hyperplane through the unit vectors is used.
+func extremePointHyperplane(points wfg.Front) *mat.VecDense {
+	objectiveCount := len(points[0])
+	extremes := mat.NewDense(objectiveCount, objectiveCount, nil)
+	for i := range objectiveCount {
+		best := 0
+		for j := range points {
+			if points[j][i] > points[best][i] {
+				best = j
+			}
+		}
+		extremes.SetRow(i, points[best])
+	}
+	ones := make([]float64, objectiveCount)
+	for i := range ones {
+		ones[i] = 1
+	}
+	normal := mat.NewVecDense(objectiveCount, nil)
+	if err := normal.SolveVec(extremes, mat.NewVecDense(objectiveCount, ones)); err != nil {
+		return mat.NewVecDense(objectiveCount, ones)
+	}
+	for i := range objectiveCount {
+		if math.IsNaN(normal.AtVec(i)) || math.IsInf(normal.AtVec(i), 0) {
+			return mat.NewVecDense(objectiveCount, ones)
+		}
+	}
+	return normal
+}
+
+// weightedSumSelector scores each network by the weighted sum of its normalized scores
+func weightedSumSelector(weights []float64) frontSelector {
+	return func(front []*graph.Network) map[*graph.Network]float64 {
+		points := normalizedFront(front)
+		summary := make(map[*graph.Network]float64, len(front))
+		for j, network := range front {
+			for i, value := range points[j] {
+				summary[network] += weights[i] * value
+			}
+		}
+		return summary
+	}
+}
+
+// hvReferenceOffset places the reference point below the normalized nadir point,
+// such that the extreme points of the front have a positive hypervolume contribution
+const hvReferenceOffset = 0.1
+
+// hvContributionSelector scores each network by its exclusive hypervolume contribution to the normalized front
+func hvContributionSelector(front []*graph.Network) map[*graph.Network]float64 {
+	points := normalizedFront(front)
+	for _, point := range points {
+		for i := range point {
+			point[i] += hvReferenceOffset
+		}
+	}
+	contributions := wfg.Contributions(points)
+	summary := make(map[*graph.Network]float64, len(front))
+	for j, network := range front {
+		summary[network] = contributions[j]
+	}
+	return summary
+}
@@ -0,0 +1,13 @@
+package run
+
+import (
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/pathfinding"
+)
+
+// NetworkStats is the entry point for the network statistics
+func NetworkStats(args *arguments.Common) {
+	fileio.CreateDirKeepContent(args.OutputFolder)
+	pathfinding.WriteNetworkStats(args)
+}
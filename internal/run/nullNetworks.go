@@ -0,0 +1,118 @@
+package run
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/graph"
+	"github.com/MarchalLab/gonetic/internal/interpretation"
+	"github.com/MarchalLab/gonetic/internal/pathfinding"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+const nullNetworksDirectoryName = "null-networks"
+
+// pipeline runs the full analysis with the given arguments
+type pipeline func(args *arguments.Common)
+
+// selectedNames are the genes and interactions of the selected network of each score type, by name
+type selectedNames map[string]map[string]struct{}
+
+// runNullNetworks runs the full pipeline on degree-preserving randomizations of the network, and reports how often the
+// genes and interactions of the selected networks are recovered. Genes and interactions that are recovered from most
+// randomized networks are explained by the degree distribution rather than by the network structure.
+func runNullNetworks(args *arguments.Common, run pipeline, genesOfInterestData ...readers.FileData) {
+	args.Info("Running null networks", "networks", args.NullNetworks)
+	directory := filepath.Join(args.OutputFolder, nullNetworksDirectoryName)
+	fileio.CreateEmptyDir(directory)
+
+	// the selected networks of the run itself
+	observed := NewInterpretation(args)
+	orderedConditions := observed.orderConditions(interpretation.NewGenesOfInterestMap(args.GeneIDMap, genesOfInterestData))
+	observedGenes, observedInteractions := observed.selectedNames(orderedConditions)
+
+	recoveredGenes := make(map[string]map[string]int)
+	recoveredInteractions := make(map[string]map[string]int)
+	for i, networkFile := range pathfinding.WriteRandomizedNetworks(args, args.NullNetworks, directory) {
+		// each null network runs the whole pipeline on the randomized network only
+		nullArgs := rerunArgs(args, filepath.Join(directory, strconv.Itoa(i)))
+		nullArgs.NetworkFiles = []string{networkFile}
+		nullArgs.BannedNetworkFiles = nil
+		nullArgs.NetworkFormat = readers.NetworkFormatGonetic
+		nullArgs.NetworkTypeMapping = ""
+		nullArgs.NetworkSources = ""
+		nullArgs.NullNetworks = 0
+		nullArgs.Bootstrap = 0
+		nullArgs.LeaveOneOut = false
+		nullArgs.SkipPathFinding = false
+		nullArgs.SkipOptimization = false
+		args.Info("Null network", "network", i, "outputFolder", nullArgs.OutputFolder)
+
+		run(nullArgs)
+		nullGenes, nullInteractions := NewInterpretation(nullArgs).selectedNames(orderedConditions)
+		countRecovered(observedGenes, nullGenes, recoveredGenes)
+		countRecovered(observedInteractions, nullInteractions, recoveredInteractions)
+	}
+
+	// write the recovery reports
+	writeRecovery(args, filepath.Join(directory, "geneRecovery.txt"), "#scoreType\tgene\trecovered\tfraction", observedGenes, recoveredGenes)
+	writeRecovery(args, filepath.Join(directory, "interactionRecovery.txt"), "#scoreType\tfrom\tto\ttype\trecovered\tfraction", observedInteractions, recoveredInteractions)
+}
+
+// selectedNames returns the genes and the interactions of the selected network of each score type
+func (runner interpretationRunner) selectedNames(orderedConditions types.Conditions) (selectedNames, selectedNames) {
+	genes := make(selectedNames)
+	interactions := make(selectedNames)
+	for _, ranked := range runner.rankAll(orderedConditions) {
+		genes[ranked.scoreType], interactions[ranked.scoreType] = runner.networkNames(ranked.network)
+	}
+	return genes, interactions
+}
+
+// networkNames returns the names of the genes and the interactions of the network
+func (runner interpretationRunner) networkNames(network *graph.Network) (map[string]struct{}, map[string]struct{}) {
+	genes := make(map[string]struct{})
+	interactions := make(map[string]struct{})
+	for id := range *network.Probabilities() {
+		from := string(runner.GeneIDMap.GetNameFromID(id.From()))
+		to := string(runner.GeneIDMap.GetNameFromID(id.To()))
+		genes[from] = struct{}{}
+		genes[to] = struct{}{}
+		interactions[fmt.Sprintf("%s\t%s\t%s", from, to, runner.InteractionStore.InteractionType(id))] = struct{}{}
+	}
+	return genes, interactions
+}
+
+// countRecovered counts the observed names that are also selected in the null network
+func countRecovered(observed, null selectedNames, recovered map[string]map[string]int) {
+	for scoreType, names := range observed {
+		if _, ok := recovered[scoreType]; !ok {
+			recovered[scoreType] = make(map[string]int)
+		}
+		for name := range names {
+			if _, ok := null[scoreType][name]; ok {
+				recovered[scoreType][name]++
+			}
+		}
+	}
+}
+
+// writeRecovery writes how often each observed name is recovered, per score type
+func writeRecovery(args *arguments.Common, fileName, header string, observed selectedNames, recovered map[string]map[string]int) {
+	lines := make([]string, 0)
+	for scoreType, names := range observed {
+		for name := range names {
+			count := recovered[scoreType][name]
+			lines = append(lines, fmt.Sprintf("%s\t%s\t%d\t%f", scoreType, name, count, float64(count)/float64(args.NullNetworks)))
+		}
+	}
+	slices.Sort(lines)
+	if err := args.WriteLinesToNewFile(fileName, []string{header}, lines); err != nil {
+		args.Error("error writing null network recovery", "err", err, "file", fileName)
+	}
+}
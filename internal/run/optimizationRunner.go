@@ -16,13 +16,15 @@ import (
 type OptimizationRunner struct {
 	*arguments.Common
 	startIsMutated bool
+	// the normal form directory of an earlier run, from which identical d-DNNFs are reused instead of compiled
+	reusableNNFs string
 }
 
 func NewOptimizationRunner(args *arguments.Common, startIsMutated bool) OptimizationRunner {
 	readers.ReadIndexes(args)
 	return OptimizationRunner{
-		args,
-		startIsMutated,
+		Common:         args,
+		startIsMutated: startIsMutated,
 	}
 }
 
@@ -140,6 +142,14 @@ func (runner OptimizationRunner) compilePathsToDDNNF(pathType string, cnfPaths m
 	if err != nil {
 		runner.Error("error in cnf.Compile", "err", err)
 	}
+	// reuse the identical d-DNNFs of an earlier run
+	if runner.reusableNNFs != "" {
+		reused, err := normalform.ReuseDDNNFs(nfDir, filepath.Join(runner.reusableNNFs, pathType))
+		if err != nil {
+			runner.Error("error in normalform.ReuseDDNNFs", "err", err)
+		}
+		runner.Info("d-DNNFs reused", "reused", reused, "path type", pathType)
+	}
 	// compile d-DNNF's
 	err = normalform.NewDDNNFCompiler(runner.Common, runner.EtcPathAsString).CompileDDNNFs(nfDir)
 	if err != nil {
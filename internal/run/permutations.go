@@ -0,0 +1,93 @@
+package run
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/interpretation"
+	"github.com/MarchalLab/gonetic/internal/pathfinding"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+const permutationsDirectoryName = "permutations"
+
+// pathFinder finds the paths of the permuted mutation and copy-number data
+type pathFinder func(args *arguments.Common, mutationFileData, cnaFileData readers.FileData)
+
+// permute reruns the path finding and a reduced optimization on permuted mutation and copy-number data, such that the
+// final rankings can be compared with null distributions. The mutations and the copy-number alterations are each
+// reshuffled across the genes of the network within each sample, preserving the number of altered genes per sample.
+// Genes outside the network, or removed from it, cannot be reached by any path and are never drawn.
+func (runner *interpretationRunner) permute(
+	qtlArgs *arguments.QTLSpecific,
+	startIsMutated bool,
+	mutationFileData readers.FileData,
+	cnaFileData readers.FileData,
+	findPaths pathFinder,
+	genesOfInterestData ...readers.FileData,
+) {
+	runner.Info("Running permutations", "permutations", qtlArgs.Permutations, "generations", qtlArgs.PermutationGenerations)
+	permutationsDirectory := filepath.Join(runner.OutputFolder, permutationsDirectoryName)
+	fileio.CreateEmptyDir(permutationsDirectory)
+
+	// the conditions are ordered as for the observed data
+	orderedConditions := runner.orderConditions(interpretation.NewGenesOfInterestMap(runner.GeneIDMap, genesOfInterestData))
+	genes := pathfinding.NetworkGenes(runner.Common)
+
+	runner.null = make(map[string][]map[string]map[types.Condition]map[types.GeneID]float64)
+	pathScoreLines := []string{"#permutation\tpathType\tprobability"}
+	for permutation := range qtlArgs.Permutations {
+		permuted, err := readers.PermuteMutations(mutationFileData, genes)
+		if err != nil {
+			runner.Error("Failed to permute mutations", "err", err)
+			panic("Cannot permute mutations")
+		}
+		permutedCNA, err := readers.PermuteMutations(cnaFileData, genes)
+		if err != nil {
+			runner.Error("Failed to permute copy-number alterations", "err", err)
+			panic("Cannot permute copy-number alterations")
+		}
+		permutedArgs := rerunArgs(runner.Common, filepath.Join(permutationsDirectory, strconv.Itoa(permutation)))
+		permutedArgs.NumGens = qtlArgs.PermutationGenerations
+		runner.Info("Permutation", "permutation", permutation, "outputFolder", permutedArgs.OutputFolder)
+
+		findPaths(permutedArgs, permuted, permutedCNA)
+		for _, pathType := range permutedArgs.PathTypes {
+			pathLists := readers.ReadPathList(permutedArgs.Logger, permutedArgs.GeneIDMap, permutedArgs.MaxPaths, pathType, permutedArgs.SldCutoff, permutedArgs.PathsFile(pathType))
+			for _, paths := range pathLists {
+				for _, path := range paths {
+					pathScoreLines = append(pathScoreLines, fmt.Sprintf("%d\t%s\t%s", permutation, pathType, strconv.FormatFloat(path.Probability, 'f', -1, 64)))
+				}
+			}
+		}
+		NewOptimizationRunner(permutedArgs, startIsMutated).Run()
+		nullRunner := NewInterpretation(permutedArgs)
+		for _, ranked := range nullRunner.rankAll(orderedConditions) {
+			runner.null[ranked.scoreType] = append(runner.null[ranked.scoreType], ranked.ranking)
+		}
+	}
+
+	// write the null distributions
+	err := runner.WriteLinesToNewFile(filepath.Join(permutationsDirectory, "pathScoreNull.txt"), pathScoreLines)
+	if err != nil {
+		runner.Error("error writing path score null distribution", "err", err)
+	}
+	geneFrequencyLines := []string{"#scoreType\tidentifier\tcondition\tgene\tpermutations"}
+	for scoreType, null := range runner.null {
+		for identifier, conditions := range interpretation.NullFrequencies(null) {
+			for condition, genes := range conditions {
+				for gene, frequency := range genes {
+					geneFrequencyLines = append(geneFrequencyLines, fmt.Sprintf("%s\t%s\t%s\t%s\t%d", scoreType, identifier, condition, runner.GeneIDMap.GetNameFromID(gene), frequency))
+				}
+			}
+		}
+	}
+	err = runner.WriteLinesToNewFile(filepath.Join(permutationsDirectory, "geneFrequencyNull.txt"), geneFrequencyLines)
+	if err != nil {
+		runner.Error("error writing gene frequency null distribution", "err", err)
+	}
+}
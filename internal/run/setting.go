@@ -0,0 +1,151 @@
+package run
+
+import (
+	"github.com/MarchalLab/gonetic/internal/common/arguments"
+	"github.com/MarchalLab/gonetic/internal/common/fileio"
+	"github.com/MarchalLab/gonetic/internal/common/types"
+	"github.com/MarchalLab/gonetic/internal/pathfinding"
+	"github.com/MarchalLab/gonetic/internal/readers"
+)
+
+// inputData are the input data of a run, the data that a setting does not use are empty
+type inputData struct {
+	mutation               readers.FileData
+	cna                    readers.FileData
+	expression             readers.FileData
+	differentialExpression readers.FileData
+}
+
+// list returns the input data in the order in which newInputData expects them
+func (data inputData) list() []readers.FileData {
+	return []readers.FileData{data.mutation, data.cna, data.expression, data.differentialExpression}
+}
+
+// newInputData recovers the input data from a list in the order of inputData.list, e.g. after resampling
+func newInputData(list []readers.FileData) inputData {
+	return inputData{
+		mutation:               list[0],
+		cna:                    list[1],
+		expression:             list[2],
+		differentialExpression: list[3],
+	}
+}
+
+// genesOfInterest returns the data that hold the genes of interest of the conditions: the mutated genes, the
+// differentially expressed genes and the genes with a copy-number alteration, when they are provided
+func (data inputData) genesOfInterest() []readers.FileData {
+	genesOfInterestData := make([]readers.FileData, 0, 3)
+	for _, fileData := range []readers.FileData{data.mutation, data.differentialExpression, data.cna} {
+		if fileData.ID != "" {
+			genesOfInterestData = append(genesOfInterestData, fileData)
+		}
+	}
+	return genesOfInterestData
+}
+
+// setting finds the paths of a setting, such that the permutations, the bootstrap replicates, the leave-one-out runs
+// and the null networks rerun it in the same way on their own data
+type setting struct {
+	*arguments.Common
+	startIsMutated bool
+	data           inputData
+	// settingArgs returns the setting specific arguments for a run with the given common arguments
+	settingArgs func(args *arguments.Common) (*arguments.Expression, *arguments.QTLSpecific, *arguments.EQTL)
+	// rerun runs the whole setting with the given arguments
+	rerun pipeline
+}
+
+// findPaths finds the paths of the data with the given arguments
+func (s setting) findPaths(args *arguments.Common, data inputData) {
+	expressionArgs, qtlArgs, eqtlArgs := s.settingArgs(args)
+	pathfinding.Run(
+		data.mutation,
+		data.cna,
+		data.expression,
+		data.differentialExpression,
+		expressionArgs,
+		qtlArgs,
+		args,
+		eqtlArgs,
+	)
+}
+
+// run finds the paths, optimizes, interprets, and runs the robustness analyses that are requested
+func (s setting) run() {
+	if !s.SkipPathFinding {
+		s.findPaths(s.Common, s.data)
+	}
+	if !s.SkipOptimization {
+		optimizer := NewOptimizationRunner(s.Common, s.startIsMutated)
+		optimizer.Run()
+	}
+	genesOfInterestData := s.data.genesOfInterest()
+	if !s.SkipInterpreter {
+		s.interpret(genesOfInterestData)
+	}
+	if s.NullNetworks > 0 {
+		runNullNetworks(s.Common, s.rerun, genesOfInterestData...)
+	}
+}
+
+// interpret runs the interpreter, preceded by the permutations and followed by the bootstrap and the leave-one-out
+// runs if they are requested
+func (s setting) interpret(genesOfInterestData []readers.FileData) {
+	interpreter := NewInterpretation(s.Common)
+	_, qtlArgs, _ := s.settingArgs(s.Common)
+	if qtlArgs.Permutations > 0 {
+		interpreter.permute(qtlArgs, s.startIsMutated, s.data.mutation, s.data.cna, func(permutedArgs *arguments.Common, permuted, permutedCNA readers.FileData) {
+			data := s.data
+			data.mutation = permuted
+			data.cna = permutedCNA
+			s.findPaths(permutedArgs, data)
+		}, genesOfInterestData...)
+	}
+	interpreter.Run(genesOfInterestData...)
+	if s.Bootstrap > 0 {
+		interpreter.bootstrap(func(bootstrapArgs *arguments.Common, resampled []readers.FileData) []readers.FileData {
+			data := newInputData(resampled)
+			s.findPaths(bootstrapArgs, data)
+			NewOptimizationRunner(bootstrapArgs, s.startIsMutated).Run()
+			return data.genesOfInterest()
+		}, s.data.list(), genesOfInterestData...)
+	}
+	if s.LeaveOneOut {
+		interpreter.leaveOneOut(func(droppedArgs *arguments.Common, dropped types.Condition, remaining []readers.FileData) []readers.FileData {
+			data := newInputData(remaining)
+			expressionArgs, qtlArgs, eqtlArgs := s.settingArgs(droppedArgs)
+			pathfinding.RunLeaveOneOut(
+				s.Common,
+				dropped,
+				data.mutation,
+				data.cna,
+				data.expression,
+				data.differentialExpression,
+				expressionArgs,
+				qtlArgs,
+				droppedArgs,
+				eqtlArgs,
+			)
+			optimizer := NewOptimizationRunner(droppedArgs, s.startIsMutated)
+			optimizer.reusableNNFs = s.NormalFormDirectory()
+			optimizer.Run()
+			return data.genesOfInterest()
+		}, s.data.list(), genesOfInterestData...)
+	}
+}
+
+// rerunArgs copies the arguments for a rerun in its own output folder, which never uses precomputed files. The rerun
+// shares the index of the run itself, such that their paths, genes and interactions can be compared.
+func rerunArgs(args *arguments.Common, outputFolder string) *arguments.Common {
+	rerun := *args
+	rerun.OutputFolder = outputFolder
+	rerun.UseIndex = ""
+	rerun.UsePaths = ""
+	rerun.UseNNFs = ""
+	rerun.SkipCompilation = false
+	rerun.Resume = false
+	fileio.CreateEmptyDir(rerun.OutputFolder)
+	rerun.WriteGeneMapFile()
+	rerun.WriteInteractionTypeMapFile()
+	return &rerun
+}
@@ -0,0 +1,91 @@
+package wfg
+
+import "sort"
+
+// HyperVolume computes the hypervolume enclosed by the front and the reference point 0*, without the external toolkit.
+// It implements the WFG algorithm (While, Bradstreet and Barone, 2012) and assumes all objectives are to be maximized.
+// It is intended for small fronts, e.g. the final front during interpretation.
+func HyperVolume(front Front) float64 {
+	points := nonDominated(front)
+	// sort decreasing on the first objective, which makes the limited sets smaller
+	sort.Slice(points, func(i, j int) bool {
+		return points[i][0] > points[j][0]
+	})
+	hv := 0.0
+	for i, point := range points {
+		hv += exclusiveHyperVolume(point, points[i+1:])
+	}
+	return hv
+}
+
+// Contributions computes the exclusive hypervolume contribution of each point in the front,
+// i.e. the hypervolume that is lost when the point is removed from the front.
+func Contributions(front Front) []float64 {
+	contributions := make([]float64, len(front))
+	for i, point := range front {
+		others := make(Front, 0, len(front)-1)
+		others = append(others, front[:i]...)
+		others = append(others, front[i+1:]...)
+		contributions[i] = exclusiveHyperVolume(point, others)
+	}
+	return contributions
+}
+
+// exclusiveHyperVolume computes the hypervolume dominated by point, but not by any point in front
+func exclusiveHyperVolume(point Point, front Front) float64 {
+	return inclusiveHyperVolume(point) - HyperVolume(limitSet(point, front))
+}
+
+// inclusiveHyperVolume computes the hypervolume dominated by a single point
+func inclusiveHyperVolume(point Point) float64 {
+	hv := 1.0
+	for _, value := range point {
+		hv *= max(value, 0)
+	}
+	return hv
+}
+
+// limitSet limits every point in the front to the box dominated by point
+func limitSet(point Point, front Front) Front {
+	limited := make(Front, 0, len(front))
+	for _, other := range front {
+		limitedPoint := make(Point, len(point))
+		for i := range point {
+			limitedPoint[i] = min(point[i], other[i])
+		}
+		limited = append(limited, limitedPoint)
+	}
+	return limited
+}
+
+// nonDominated returns the points of the front that are not weakly dominated by another point,
+// equal points are only retained once
+func nonDominated(front Front) Front {
+	result := make(Front, 0, len(front))
+	for i, point := range front {
+		dominated := false
+		for j, other := range front {
+			if i == j {
+				continue
+			}
+			if weaklyDominates(other, point) && (!weaklyDominates(point, other) || j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			result = append(result, point)
+		}
+	}
+	return result
+}
+
+// weaklyDominates returns whether p is at least as good as q in every objective
+func weaklyDominates(p, q Point) bool {
+	for i := range p {
+		if p[i] < q[i] {
+			return false
+		}
+	}
+	return true
+}
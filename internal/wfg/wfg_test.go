@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -287,3 +288,37 @@ func equalPoints(a, b wfg.Point) bool {
 func contains(content []byte, substr string) bool {
 	return strings.Contains(string(content), substr)
 }
+
+func TestHyperVolume(t *testing.T) {
+	tests := []struct {
+		name  string
+		front wfg.Front
+		want  float64
+	}{
+		{"Empty front", wfg.Front{}, 0},
+		{"Single point", wfg.Front{{2, 3}}, 6},
+		{"Two points", wfg.Front{{1, 3}, {3, 1}}, 5},
+		{"Dominated point", wfg.Front{{1, 3}, {3, 1}, {1, 1}}, 5},
+		{"Duplicate points", wfg.Front{{2, 2}, {2, 2}}, 4},
+		{"Three objectives", wfg.Front{{1, 1, 2}, {2, 2, 1}}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wfg.HyperVolume(tt.front)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("HyperVolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContributions(t *testing.T) {
+	front := wfg.Front{{1, 3}, {2, 2}, {3, 1}, {1, 1}}
+	want := []float64{1, 1, 1, 0}
+	got := wfg.Contributions(front)
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("Contributions()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}